@@ -18,10 +18,13 @@ const (
 )
 
 var (
-	outputDir string
-	dataFile  string
-	offline   bool
-	force     bool
+	outputDir  string
+	dataFile   string
+	recordFile string
+	offline    bool
+	force      bool
+	noReview   bool
+	strict     bool
 )
 
 func newApplyCommand() *cobra.Command {
@@ -34,9 +37,12 @@ func newApplyCommand() *cobra.Command {
 	}
 
 	applyCmd.Flags().StringVarP(&outputDir, "output", "o", currentDirectory, "path to the directory where the template should be applied")
-	applyCmd.Flags().StringVarP(&dataFile, "data", "d", "", "path to a json data file for filling in template data")
+	applyCmd.Flags().StringVarP(&dataFile, "data", "d", "", "path to a json or yaml data file for filling in template data")
+	applyCmd.Flags().StringVar(&recordFile, "record", "", "path to a json file to write the answers to, for use with --data")
 	applyCmd.Flags().BoolVar(&offline, "offline", false, "turns off auto-downloading/updating of templates")
 	applyCmd.Flags().BoolVarP(&force, "force", "f", false, "force overwriting of files without asking")
+	applyCmd.Flags().BoolVar(&noReview, "no-review", false, "generate without reviewing the answers")
+	applyCmd.Flags().BoolVar(&strict, "strict", false, "fail on keys missing from the data instead of rendering empty values")
 
 	return applyCmd
 }
@@ -60,7 +66,6 @@ func validateApplyFlags(cmd *cobra.Command, args []string) error {
 func executeApply(cmd *cobra.Command, args []string) error {
 	var err error
 	var defData map[string]interface{}
-	var rawData []byte
 
 	opts := getBaseOptions()
 
@@ -78,17 +83,41 @@ func executeApply(cmd *cobra.Command, args []string) error {
 	}
 
 	if !skelputil.IsBlank(dataFile) {
-		rawData, err = ioutil.ReadFile(dataFile)
-
-		if err == nil {
-			err = json.Unmarshal(rawData, &defData)
-		}
+		defData, err = skelplate.ReadDataFile(dataFile)
 	}
 
 	if err == nil {
-		gen := generator.New(opts)
 		dp := skelplate.NewDataProvider(defData)
+		dp.SetOutputDir(opts.OutputDir)
+		if !noReview {
+			dp.ReviewAnswers()
+		}
+
+		if strict {
+			dp.UseStrictMode()
+		}
+
+		opts.IncludesProvider = dp.IncludesProviderFunc
+		opts.ParentProvider = dp.ParentProviderFunc
+		opts.StrictProvider = dp.StrictProviderFunc
+		opts.FuncsReceiver = dp.SetFuncs
+
+		gen := generator.New(opts)
 		err = gen.Generate(args[0], dp.DataProviderFunc)
+
+		if err == nil && !skelputil.IsBlank(recordFile) {
+			err = writeRecordedData(recordFile, dp.RecordedData())
+		}
+	}
+
+	return err
+}
+
+func writeRecordedData(path string, recorded map[string]interface{}) error {
+	recordedBytes, err := json.MarshalIndent(recorded, "", "  ")
+
+	if err == nil {
+		err = ioutil.WriteFile(path, recordedBytes, 0600)
 	}
 
 	return err
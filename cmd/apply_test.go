@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -103,3 +106,39 @@ func TestApplyBadDataFile(t *testing.T) {
 		t.Errorf("apply error does not match")
 	}
 }
+
+func TestApplyRecord(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	tmpOutputDir, _ := ioutil.TempDir("", "skelp-output")
+	defer os.RemoveAll(tmpOutputDir)
+
+	recordPath := filepath.Join(tmpHomeDir, "answers.json")
+
+	code := Execute([]string{"apply", "../testdata/generator/simple", "--no-color", "--force", "--offline", "--homedir", tmpHomeDir, "-o", tmpOutputDir, "-d", "../testdata/generator/simple-data.json", "--record", recordPath}, out)
+
+	if code != 0 {
+		t.Fatalf("apply should not have errored: %s", out)
+	}
+
+	var recorded map[string]interface{}
+	recordedBytes, _ := ioutil.ReadFile(recordPath)
+
+	if err := json.Unmarshal(recordedBytes, &recorded); err != nil {
+		t.Fatalf("recorded answers are not json: %s\n%s", err, recordedBytes)
+	}
+
+	want := map[string]interface{}{"projectName": "myProject", "packageName": "mypackage"}
+	if !reflect.DeepEqual(recorded, want) {
+		t.Errorf("recorded answers do not match: have (%v) want (%v)", recorded, want)
+	}
+
+	// the recording can be used as the data file of the next run
+	code = Execute([]string{"apply", "../testdata/generator/simple", "--no-color", "--force", "--offline", "--homedir", tmpHomeDir, "-o", tmpOutputDir, "-d", recordPath}, out)
+
+	if code != 0 {
+		t.Errorf("apply with recorded answers should not have errored: %s", out)
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/brainicorn/skelp/generator"
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/spf13/cobra"
+)
+
+const (
+	dataFormatJSON   = "json"
+	dataFormatYAML   = "yaml"
+	errDataBadFormat = "--format must be either json or yaml"
+)
+
+var (
+	dataInitFormat  string
+	dataInitOffline bool
+)
+
+func newDataCommand() *cobra.Command {
+	dataCmd := &cobra.Command{
+		Use:   "data",
+		Short: "work with data files for applying templates",
+		Long:  `work with data files for applying templates`,
+	}
+
+	dataCmd.AddCommand(newDataInitCommand())
+	return dataCmd
+}
+
+func newDataInitCommand() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init [git-url|file-path|alias]",
+		Short: "Print a sample data file for a template",
+		Long: `Print a sample data file for a template.
+
+Writes every variable a data file can provide, with its default rendered the same way apply
+renders it, in the order skelp.json declares them. Yaml output includes each variable's question
+and rules as a comment. Json can't hold comments, use skelp describe --json to see them.
+Variables of included templates follow, unless the include's data already maps them.
+
+If no template is given the current directory is used.
+
+  skelp data init myalias > data.json
+  skelp apply myalias --data data.json`,
+		PreRunE: validateDataInitFlags,
+		RunE:    executeDataInit,
+	}
+
+	initCmd.Flags().StringVar(&dataInitFormat, "format", dataFormatJSON, "output format, either json or yaml")
+	initCmd.Flags().BoolVar(&dataInitOffline, "offline", false, "turns off auto-downloading/updating of templates")
+
+	return initCmd
+}
+
+func validateDataInitFlags(cmd *cobra.Command, args []string) error {
+	if dataInitFormat != dataFormatJSON && dataInitFormat != dataFormatYAML {
+		return newUserError(errDataBadFormat)
+	}
+
+	return nil
+}
+
+func executeDataInit(cmd *cobra.Command, args []string) error {
+	var err error
+	var templatePath string
+	var samples []skelplate.SampleValue
+	var sampleBytes []byte
+
+	templateID := "."
+	if len(args) > 0 {
+		templateID = args[0]
+	}
+
+	opts := getBaseOptions()
+
+	if dataInitOffline {
+		opts.CheckForUpdates = false
+		opts.Download = false
+	}
+
+	dp := skelplate.NewDataProvider(nil)
+	opts.ParentProvider = dp.ParentProviderFunc
+
+	gen := generator.New(opts)
+
+	templatePath, err = gen.LocalTemplatePath(templateID)
+
+	if err == nil {
+		templatePath, err = filepath.Abs(templatePath)
+	}
+
+	// walking the layers finds the templates the descriptor extends
+	if err == nil {
+		_, err = gen.TemplateLayers(templatePath)
+	}
+
+	if err == nil {
+		samples, err = dp.SampleData(templatePath, includeResolver(gen))
+	}
+
+	if err == nil {
+		if dataInitFormat == dataFormatYAML {
+			sampleBytes, err = skelplate.SampleYAML(samples)
+		} else {
+			sampleBytes, err = skelplate.SampleJSON(samples)
+		}
+	}
+
+	if err == nil {
+		fmt.Fprint(cmd.OutOrStdout(), string(sampleBytes))
+	}
+
+	return err
+}
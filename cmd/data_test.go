@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDataInitJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"data", "init", "../testdata/generator/extends/child", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("data init should not have errored: %s", out)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &data); err != nil {
+		t.Fatalf("data init output is not json: %s\n%s", err, out)
+	}
+
+	want := map[string]interface{}{"projectName": "", "license": "Apache-2.0", "owner": "brainicorn"}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("data does not match: have (%v) want (%v)", data, want)
+	}
+}
+
+func TestDataInitYAML(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"data", "init", "../testdata/generator/simple", "--format", "yaml", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("data init should not have errored: %s", out)
+	}
+
+	want := `"projectName": "" # Enter a project name: (string, required, min 3)`
+	if !strings.HasPrefix(out.String(), want) {
+		t.Errorf("data init output does not match: have (%s) want (%s)", out, want)
+	}
+}
+
+func TestDataInitBadFormat(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"data", "init", "../testdata/generator/simple", "--format", "xml", "--no-color", "--homedir", tmpHomeDir}, out)
+
+	if code == 0 {
+		t.Errorf("data init should have errored")
+	}
+
+	if !strings.HasPrefix(out.String(), errDataBadFormat) {
+		t.Errorf("data init error does not match: have (%s)", out)
+	}
+}
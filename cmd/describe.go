@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/brainicorn/skelp/generator"
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/brainicorn/skelp/skelputil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	describeDateLayout = "2006-01-02 15:04:05 MST"
+	describeIndent     = "  "
+)
+
+var (
+	describeJSON    bool
+	describeOffline bool
+)
+
+func newDescribeCommand() *cobra.Command {
+	describeCmd := &cobra.Command{
+		Use:     "describe [git-url|file-path|alias]",
+		Aliases: []string{"info"},
+		Short:   "Show a template's information, variables and files",
+		Long: `Show a template's information, variables and files.
+
+Describe resolves the template the same way apply does and prints the author, description,
+repository and dates from skelp.json, each variable with its kind, default, prompt and rules,
+and the tree of files under the templates folder.
+
+If no template is given the current directory is described.`,
+		RunE: executeDescribe,
+	}
+
+	describeCmd.Flags().BoolVar(&describeJSON, "json", false, "print the description as json")
+	describeCmd.Flags().BoolVar(&describeOffline, "offline", false, "turns off auto-downloading/updating of templates")
+
+	return describeCmd
+}
+
+func executeDescribe(cmd *cobra.Command, args []string) error {
+	var err error
+	var templatePath string
+	var templateDirs []string
+	var desc skelplate.TemplateDescription
+
+	templateID := "."
+	if len(args) > 0 {
+		templateID = args[0]
+	}
+
+	opts := getBaseOptions()
+
+	if describeOffline {
+		opts.CheckForUpdates = false
+		opts.Download = false
+	}
+
+	dp := skelplate.NewDataProvider(nil)
+	opts.ParentProvider = dp.ParentProviderFunc
+
+	gen := generator.New(opts)
+
+	templatePath, err = gen.LocalTemplatePath(templateID)
+
+	if err == nil {
+		templatePath, err = filepath.Abs(templatePath)
+	}
+
+	if err == nil {
+		templateDirs, err = gen.TemplateLayers(templatePath)
+	}
+
+	if err == nil {
+		desc, err = dp.Describe(templatePath, templateDirs)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if describeJSON {
+		var jsonBytes []byte
+		jsonBytes, err = json.MarshalIndent(desc, "", describeIndent)
+
+		if err == nil {
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		}
+
+		return err
+	}
+
+	writeDescription(cmd.OutOrStdout(), desc)
+
+	return nil
+}
+
+func writeDescription(w io.Writer, desc skelplate.TemplateDescription) {
+	fmt.Fprintf(w, "author:      %s\n", desc.Author)
+	fmt.Fprintf(w, "description: %s\n", desc.Description)
+	fmt.Fprintf(w, "repository:  %s\n", desc.Repository)
+	fmt.Fprintf(w, "created:     %s\n", desc.Created.Format(describeDateLayout))
+	fmt.Fprintf(w, "modified:    %s\n", desc.Modified.Format(describeDateLayout))
+
+	if !skelputil.IsBlank(desc.Extends) {
+		fmt.Fprintf(w, "extends:     %s\n", desc.Extends)
+	}
+
+	for _, inc := range desc.Includes {
+		fmt.Fprintf(w, "includes:    %s\n", inc.TemplateID)
+	}
+
+	fmt.Fprintln(w, "\nvariables:")
+	writeVariables(w, desc.Variables, describeIndent)
+
+	fmt.Fprintln(w, "\nfiles:")
+	for _, f := range desc.Files {
+		trimmed := strings.TrimSuffix(f, "/")
+		depth := strings.Count(trimmed, "/")
+		name := trimmed[strings.LastIndex(trimmed, "/")+1:]
+
+		if strings.HasSuffix(f, "/") {
+			name += "/"
+		}
+
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat(describeIndent, depth+1), name)
+	}
+}
+
+func writeVariables(w io.Writer, vars []skelplate.VariableDescription, indent string) {
+	for _, v := range vars {
+		kind := v.Kind
+		if !skelputil.IsBlank(v.Type) {
+			kind += ", " + v.Type
+		}
+
+		fmt.Fprintf(w, "%s%s (%s)\n", indent, v.Name, kind)
+
+		details := indent + describeIndent
+		if v.Default != nil {
+			fmt.Fprintf(w, "%sdefault:  %v\n", details, v.Default)
+		}
+
+		if !skelputil.IsBlank(v.Value) {
+			fmt.Fprintf(w, "%svalue:    %s\n", details, v.Value)
+		}
+
+		if !skelputil.IsBlank(v.Prompt) {
+			fmt.Fprintf(w, "%sprompt:   %s\n", details, v.Prompt)
+		}
+
+		if !skelputil.IsBlank(v.Help) {
+			fmt.Fprintf(w, "%shelp:     %s\n", details, v.Help)
+		}
+
+		if !skelputil.IsBlank(v.DocsURL) {
+			fmt.Fprintf(w, "%sdocs:     %s\n", details, v.DocsURL)
+		}
+
+		rules := []string{}
+		if v.Required {
+			rules = append(rules, "required")
+		}
+
+		if v.Password {
+			rules = append(rules, "password")
+		}
+
+		if v.Min != 0 {
+			rules = append(rules, fmt.Sprintf("min %v", v.Min))
+		}
+
+		if v.Max != 0 {
+			rules = append(rules, fmt.Sprintf("max %v", v.Max))
+		}
+
+		if v.Multiple {
+			rules = append(rules, "multiple choice")
+		}
+
+		if v.MinPicks != 0 {
+			rules = append(rules, fmt.Sprintf("min picks %d", v.MinPicks))
+		}
+
+		if v.MaxPicks != 0 {
+			rules = append(rules, fmt.Sprintf("max picks %d", v.MaxPicks))
+		}
+
+		if v.Repeated {
+			rules = append(rules, "repeated")
+		}
+
+		if !skelputil.IsBlank(v.Key) {
+			rules = append(rules, "keyed by "+v.Key)
+		}
+
+		if len(rules) > 0 {
+			fmt.Fprintf(w, "%srules:    %s\n", details, strings.Join(rules, ", "))
+		}
+
+		if len(v.Choices) > 0 {
+			choices := []string{}
+			for _, c := range v.Choices {
+				if skelputil.IsBlank(c.Label) {
+					choices = append(choices, c.Value)
+				} else {
+					choices = append(choices, fmt.Sprintf("%s (%s)", c.Value, c.Label))
+				}
+			}
+
+			fmt.Fprintf(w, "%schoices:  %s\n", details, strings.Join(choices, ", "))
+		}
+
+		writeVariables(w, v.Variables, details)
+	}
+}
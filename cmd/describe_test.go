@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/brainicorn/skelp/skelplate"
+)
+
+func TestDescribeText(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"describe", "../testdata/generator/simple", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("describe should not have errored: %s", out)
+	}
+
+	for _, want := range []string{
+		"author:      brainicorn\n",
+		"  projectName (complex)\n    default:  \n    prompt:   Enter a project name:\n    rules:    required, min 3\n",
+		"files:\n  README.md\n  {{.packageName}}/\n    {{.packageName}}.go\n  {{.projectName}}.md\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("describe output is missing (%s):\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"info", "../testdata/generator/extends/child", "--json", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("describe should not have errored: %s", out)
+	}
+
+	var desc skelplate.TemplateDescription
+	if err := json.Unmarshal(out.Bytes(), &desc); err != nil {
+		t.Fatalf("describe output is not json: %s\n%s", err, out)
+	}
+
+	if desc.Extends != "../base" || len(desc.Variables) != 3 {
+		t.Errorf("description does not match the merged descriptor: %s", out)
+	}
+
+	if !reflect.DeepEqual(desc.Files, []string{"LICENSE.txt", "README.md"}) {
+		t.Errorf("files do not match: have (%v)", desc.Files)
+	}
+}
@@ -21,3 +21,25 @@ func isUserError(err error) bool {
 
 	return false
 }
+
+// exitError fails the command without printing anything, the command has already reported
+// what went wrong.
+type exitError struct {
+	s string
+}
+
+func (ee exitError) Error() string {
+	return ee.s
+}
+
+func newExitError(s string) exitError {
+	return exitError{s: s}
+}
+
+func isExitError(err error) bool {
+	if _, ok := err.(exitError); ok {
+		return true
+	}
+
+	return false
+}
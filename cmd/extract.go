@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/brainicorn/skelp/skelputil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	extractSkelplateSuffix = "-skelplate"
+	extractCreated         = "extracted skelplate to %s"
+	errExtractBadVar       = "%s is not a valid --var flag, use name=value"
+	errExtractNotADir      = "%s is not a valid project directory"
+)
+
+var (
+	extractOutput       string
+	extractVars         []string
+	extractCaseVariants bool
+	extractAuthor       string
+	extractDescription  string
+)
+
+func newExtractCommand() *cobra.Command {
+	extractCmd := &cobra.Command{
+		Use:   "extract [projectDir]",
+		Short: "Create a skelplate from an existing project",
+		Long: `Create a skelplate from an existing project.
+
+Copies the project (or the current directory) into the templates folder of a new skelplate and
+replaces every occurrence of each --var value in file contents and paths with a template
+expression for the variable. Existing {{ delimiters are escaped, files ignored by the project's
+.gitignore files are skipped and a skelp.json declaring the variables is written.
+
+With --case-variants the camel, pascal, snake, kebab, upper and lower case forms of each value are
+replaced too. Give values with separators (e.g. my-service) so the case forms can be found.
+
+  skelp extract ./myservice --var projectName=my-service --var org=acme --case-variants`,
+		PreRunE: validateExtractFlags,
+		RunE:    executeExtract,
+	}
+
+	extractCmd.Flags().StringVarP(&extractOutput, "output", "o", "", "path to the new skelplate (defaults to <projectDir>-skelplate)")
+	extractCmd.Flags().StringArrayVar(&extractVars, "var", []string{}, "a variable to extract as name=value, can be repeated")
+	extractCmd.Flags().BoolVar(&extractCaseVariants, "case-variants", false, "also replace the case variants of each value")
+	extractCmd.Flags().StringVar(&extractAuthor, "author", "", "the author of the skelplate")
+	extractCmd.Flags().StringVar(&extractDescription, "description", "", "the description of the skelplate")
+
+	return extractCmd
+}
+
+func validateExtractFlags(cmd *cobra.Command, args []string) error {
+	projectDir := extractProjectDir(args)
+
+	if !skelputil.PathExists(projectDir) {
+		return newUserError(fmt.Sprintf(errExtractNotADir, projectDir))
+	}
+
+	for _, v := range extractVars {
+		if !strings.Contains(v, "=") {
+			return newUserError(fmt.Sprintf(errExtractBadVar, v))
+		}
+	}
+
+	jsonPath := filepath.Join(extractSkelplateDir(projectDir), "skelp.json")
+
+	if skelputil.PathExists(jsonPath) {
+		return newUserError(fmt.Sprintf(skelplate.ErrSkelplateExists, jsonPath))
+	}
+
+	return nil
+}
+
+func executeExtract(cmd *cobra.Command, args []string) error {
+	projectDir := extractProjectDir(args)
+	skelplateDir := extractSkelplateDir(projectDir)
+
+	opts := skelplate.ExtractOptions{
+		CaseVariants: extractCaseVariants,
+		Author:       extractAuthor,
+		Description:  extractDescription,
+	}
+
+	for _, v := range extractVars {
+		parts := strings.SplitN(v, "=", 2)
+		opts.Vars = append(opts.Vars, skelplate.ExtractVar{Name: strings.TrimSpace(parts[0]), Literal: parts[1]})
+	}
+
+	err := skelplate.Extract(projectDir, skelplateDir, opts)
+
+	if err == nil {
+		cmd.Println(fmt.Sprintf(extractCreated, skelplateDir))
+	}
+
+	return err
+}
+
+func extractProjectDir(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+
+	return "."
+}
+
+func extractSkelplateDir(projectDir string) string {
+	if !skelputil.IsBlank(extractOutput) {
+		return extractOutput
+	}
+
+	absProject, err := filepath.Abs(projectDir)
+	if err != nil {
+		absProject = projectDir
+	}
+
+	return filepath.Join(filepath.Dir(absProject), filepath.Base(absProject)+extractSkelplateSuffix)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+func TestExtractCommand(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-extract-cmd")
+	defer os.RemoveAll(tmpDir)
+
+	skelplateDir := filepath.Join(tmpDir, "simple-skelplate")
+	code := Execute([]string{"extract", "../testdata/generator/simple/templates", "-o", skelplateDir, "--var", "greeting=Hello", "--author", "brainicorn", "--no-color", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("extract should not have errored: %s", out)
+	}
+
+	if !skelputil.PathExists(filepath.Join(skelplateDir, "skelp.json")) {
+		t.Errorf("skelp.json was not written")
+	}
+}
+
+func TestExtractBadVar(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"extract", "../testdata/generator/simple", "--var", "greeting", "--no-color", "--homedir", tmpHomeDir}, out)
+
+	if code == 0 {
+		t.Errorf("extract should have errored")
+	}
+
+	if !strings.HasPrefix(out.String(), "greeting is not a valid --var flag") {
+		t.Errorf("extract error does not match: have (%s)", out)
+	}
+}
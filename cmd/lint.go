@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/brainicorn/skelp/generator"
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/spf13/cobra"
+)
+
+const (
+	lintFormatText      = "text"
+	lintFormatJSON      = "json"
+	errLintBadFormat    = "--format must be either text or json"
+	errLintProblemsFmt  = "%d problem(s) found in template"
+	lintNoProblemsFound = "no problems found"
+)
+
+var (
+	lintFormat  string
+	lintOffline bool
+)
+
+func newLintCommand() *cobra.Command {
+	lintCmd := &cobra.Command{
+		Use:   "lint [git-url|file-path|alias]",
+		Short: "Validate a template without applying it",
+		Long: `Validate a template without applying it.
+
+Lint checks skelp.json against the json schema, parses every file and file name template
+and reports variables that are used but never declared (and the reverse), duplicate variable
+names and defaults that conflict with their own type, min, max or choices. Variables declared by
+included templates count as used since the included templates are given the same data.
+
+If no template is given the current directory is linted.`,
+		PreRunE: validateLintFlags,
+		RunE:    executeLint,
+	}
+
+	lintCmd.Flags().StringVar(&lintFormat, "format", lintFormatText, "output format, either text or json")
+	lintCmd.Flags().BoolVar(&lintOffline, "offline", false, "turns off auto-downloading/updating of templates")
+
+	return lintCmd
+}
+
+func validateLintFlags(cmd *cobra.Command, args []string) error {
+	if lintFormat != lintFormatText && lintFormat != lintFormatJSON {
+		return newUserError(errLintBadFormat)
+	}
+
+	return nil
+}
+
+func executeLint(cmd *cobra.Command, args []string) error {
+	var err error
+	var templatePath string
+	var templateDirs []string
+	var problems []skelplate.LintProblem
+
+	templateID := "."
+	if len(args) > 0 {
+		templateID = args[0]
+	}
+
+	opts := getBaseOptions()
+
+	if lintOffline {
+		opts.CheckForUpdates = false
+		opts.Download = false
+	}
+
+	dp := skelplate.NewDataProvider(nil)
+	opts.ParentProvider = dp.ParentProviderFunc
+
+	gen := generator.New(opts)
+
+	templatePath, err = gen.LocalTemplatePath(templateID)
+
+	if err == nil {
+		templatePath, err = filepath.Abs(templatePath)
+	}
+
+	if err == nil {
+		templateDirs, err = gen.TemplateLayers(templatePath)
+	}
+
+	if err == nil {
+		problems, err = dp.Lint(templatePath, templateDirs, includeResolver(gen))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if lintFormat == lintFormatJSON {
+		var jsonBytes []byte
+		jsonBytes, err = json.MarshalIndent(problems, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		cmd.Println(string(jsonBytes))
+	} else {
+		for _, p := range problems {
+			cmd.Println(p.String())
+		}
+
+		if len(problems) < 1 {
+			cmd.Println(lintNoProblemsFound)
+		}
+	}
+
+	if len(problems) > 0 {
+		return newExitError(fmt.Sprintf(errLintProblemsFmt, len(problems)))
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"lint", "../testdata/generator/simple", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		fmt.Println(out)
+		t.Errorf("lint should not have errored")
+	}
+
+	if strings.TrimSpace(out.String()) != lintNoProblemsFound {
+		t.Errorf("lint output does not match: have (%s) want (%s)", out, lintNoProblemsFound)
+	}
+}
+
+func TestLintProblemsJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"lint", "../testdata/lint/broken", "--format", "json", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 1 {
+		t.Errorf("lint should have errored")
+	}
+
+	var problems []map[string]interface{}
+	err := json.Unmarshal(out.Bytes(), &problems)
+
+	if err != nil {
+		t.Fatalf("lint output is not json: %s\n%s", err, out)
+	}
+
+	if len(problems) < 1 || problems[0]["file"] != "skelp.json" {
+		t.Errorf("wrong problems: %v", problems)
+	}
+}
+
+func TestLintBadFormat(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"lint", "../testdata/generator/simple", "--format", "xml", "--no-color", "--homedir", tmpHomeDir}, out)
+
+	if code == 0 {
+		t.Errorf("lint should have errored")
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	if lines[0] != errLintBadFormat {
+		t.Errorf("lint error does not match: have (%s) want (%s)", lines[0], errLintBadFormat)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/brainicorn/skelp/skelputil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	newSkelplateCreated = "created skelplate in %s"
+)
+
+func newNewCommand() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "new [dir]",
+		Short: "Create a new skelplate",
+		Long: `Create a new skelplate.
+
+Asks questions about the template and its variables, then writes skelp.json and a templates
+folder to the given directory (or the current directory).`,
+		PreRunE: validateNewFlags,
+		RunE:    executeNew,
+	}
+
+	return newCmd
+}
+
+func validateNewFlags(cmd *cobra.Command, args []string) error {
+	jsonPath := filepath.Join(newSkelplateDir(args), "skelp.json")
+
+	if skelputil.PathExists(jsonPath) {
+		return newUserError(fmt.Sprintf(skelplate.ErrSkelplateExists, jsonPath))
+	}
+
+	return nil
+}
+
+func executeNew(cmd *cobra.Command, args []string) error {
+	var err error
+	var descriptor skelplate.SkelplateDescriptor
+
+	dir := newSkelplateDir(args)
+	builder := skelplate.NewSkelplateBuilder()
+
+	descriptor, err = builder.BuildDescriptor()
+
+	if err == nil {
+		err = skelplate.CreateSkelplate(dir, descriptor)
+	}
+
+	if err == nil {
+		cmd.Println(fmt.Sprintf(newSkelplateCreated, dir))
+	}
+
+	return err
+}
+
+func newSkelplateDir(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+
+	return "."
+}
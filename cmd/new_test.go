@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewExistingSkelplate(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"new", "../testdata/generator/simple", "--no-color", "--homedir", tmpHomeDir}, out)
+
+	if code == 0 {
+		t.Errorf("new should have errored")
+	}
+
+	if !strings.HasPrefix(out.String(), "skelp.json already exists") {
+		t.Errorf("new error does not match: have (%s)", out)
+	}
+}
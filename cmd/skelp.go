@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/brainicorn/skelp/generator"
+	"github.com/brainicorn/skelp/skelplate"
 	"github.com/brainicorn/skelp/skelputil"
 	"github.com/mgutz/ansi"
 	"github.com/spf13/cobra"
@@ -59,6 +61,12 @@ func addCommandsToRoot(cmd *cobra.Command) {
 	cmd.AddCommand(newApplyCommand())
 	cmd.AddCommand(newAliasCommand())
 	cmd.AddCommand(newBashmeCommand())
+	cmd.AddCommand(newDataCommand())
+	cmd.AddCommand(newDescribeCommand())
+	cmd.AddCommand(newLintCommand())
+	cmd.AddCommand(newNewCommand())
+	cmd.AddCommand(newExtractCommand())
+	cmd.AddCommand(newTestCommand())
 }
 
 // This is called by main.main(). It only needs to happen once to the rootCmd.
@@ -76,6 +84,10 @@ func Execute(args []string, out io.Writer) int {
 
 	if cmd, err = skelpCmd.ExecuteC(); err != nil {
 		exitcode = 1
+		if isExitError(err) {
+			return exitcode
+		}
+
 		if isUserError(err) {
 			cmd.Println(colorError(err.Error()))
 			cmd.Println(cmd.UsageString())
@@ -101,6 +113,24 @@ func getBaseOptions() generator.SkelpOptions {
 	return opts
 }
 
+// includeResolver finds the templates included by a template the way applying it does, so their
+// descriptors can be read along with the descriptors they extend.
+func includeResolver(gen *generator.SkelpGenerator) skelplate.IncludeResolver {
+	return func(templateRoot, templateID string) (string, error) {
+		incPath, err := gen.IncludeTemplatePath(templateRoot, templateID)
+
+		if err == nil {
+			incPath, err = filepath.Abs(incPath)
+		}
+
+		if err == nil {
+			_, err = gen.TemplateLayers(incPath)
+		}
+
+		return incPath, err
+	}
+}
+
 func colorError(s string) string {
 	return ansi.Color(s, "red+b")
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/brainicorn/skelp/skelptest"
+	"github.com/spf13/cobra"
+)
+
+const (
+	testCasePassed    = "PASS %s"
+	testCaseFailed    = "FAIL %s"
+	testCaseUpdated   = "UPDATED %s"
+	errTestsFailedFmt = "%d of %d test case(s) failed"
+)
+
+var (
+	testUpdate  bool
+	testOffline bool
+)
+
+func newTestCommand() *cobra.Command {
+	testCmd := &cobra.Command{
+		Use:   "test [git-url|file-path|alias]",
+		Short: "Run a template's test cases",
+		Long: `Run a template's test cases.
+
+Each folder under the template's tests folder is a case holding an optional data.json (or
+data.yaml) file and an expected folder. Every case is applied without prompting to a temp
+directory, using defaults for anything missing from the data file, and the output is compared
+to the expected folder.
+
+Use --update to replace the expected folders with the current output.
+
+If no template is given the current directory is tested.`,
+		RunE: executeTest,
+	}
+
+	testCmd.Flags().BoolVar(&testUpdate, "update", false, "replace the expected output of every case with the rendered output")
+	testCmd.Flags().BoolVar(&testOffline, "offline", false, "turns off auto-downloading/updating of templates")
+
+	return testCmd
+}
+
+func executeTest(cmd *cobra.Command, args []string) error {
+	templateID := "."
+	if len(args) > 0 {
+		templateID = args[0]
+	}
+
+	opts := skelptest.Options{
+		Update:       testUpdate,
+		SkelpOptions: getBaseOptions(),
+	}
+
+	if testOffline {
+		opts.SkelpOptions.CheckForUpdates = false
+		opts.SkelpOptions.Download = false
+	}
+
+	results, err := skelptest.RunCases(templateID, opts)
+
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Updated:
+			cmd.Println(fmt.Sprintf(testCaseUpdated, result.Name))
+		case result.Passed():
+			cmd.Println(fmt.Sprintf(testCasePassed, result.Name))
+		default:
+			failed++
+			cmd.Println(colorError(fmt.Sprintf(testCaseFailed, result.Name)))
+
+			for _, p := range result.Problems {
+				cmd.Println("  " + p)
+			}
+		}
+	}
+
+	if failed > 0 {
+		msg := fmt.Sprintf(errTestsFailedFmt, failed, len(results))
+		cmd.Println(msg)
+
+		return newExitError(msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTestCommandPasses(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"test", "../testdata/skelptest/beer", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code != 0 {
+		t.Fatalf("test should not have errored: %s", out)
+	}
+
+	if out.String() != "PASS defaults\nPASS ipa\n" {
+		t.Errorf("test output does not match: have (%s)", out)
+	}
+}
+
+func TestTestCommandFails(t *testing.T) {
+	out := new(bytes.Buffer)
+	tmpHomeDir, _ := ioutil.TempDir("", "skelp-custom-home")
+	defer os.RemoveAll(tmpHomeDir)
+
+	code := Execute([]string{"test", "../testdata/skelptest/broken", "--no-color", "--offline", "--homedir", tmpHomeDir}, out)
+
+	if code == 0 {
+		t.Errorf("test should have errored")
+	}
+
+	if !strings.HasSuffix(out.String(), "1 of 1 test case(s) failed\n") {
+		t.Errorf("test output does not match: have (%s)", out)
+	}
+}
@@ -1,8 +1,8 @@
 package executor
 
 import (
-	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,31 +20,57 @@ const (
 type WalkingExecutor struct {
 	funcMap  map[string]interface{}
 	tOptions []string
+	strict   bool
 }
 
+// New creates an executor that runs templates with funcMap and options. When the options are
+// skelputil.StrictTemplateOptions, files and directories with missing keys aren't written and the
+// keys are returned in a MissingKeysError once every template has run.
 func New(funcMap map[string]interface{}, options []string) *WalkingExecutor {
 	return &WalkingExecutor{
 		funcMap:  funcMap,
 		tOptions: options,
+		strict:   skelputil.IsStrict(options),
 	}
 }
 
 func (we *WalkingExecutor) Execute(tmplDir, outputDir string, tmplData interface{}, owProvider provider.OverwriteProvider) error {
+	return we.ExecuteLayers([]string{tmplDir}, outputDir, tmplData, owProvider)
+}
+
+// ExecuteLayers applies several template dirs to the output directory as if they were one.
+// When more than one layer renders the same file the earlier layer wins, so tmplDirs should be
+// ordered from the most specific template to the most general.
+func (we *WalkingExecutor) ExecuteLayers(tmplDirs []string, outputDir string, tmplData interface{}, owProvider provider.OverwriteProvider) error {
 	var err error
 
-	if skelputil.IsBlank(tmplDir) || !skelputil.PathExists(tmplDir) || skelputil.DirIsEmpty(tmplDir) {
-		err = fmt.Errorf(ErrNoTemplatesFound, tmplDir)
+	layers := []string{}
+	for _, tmplDir := range tmplDirs {
+		if !skelputil.IsBlank(tmplDir) && skelputil.PathExists(tmplDir) && !skelputil.DirIsEmpty(tmplDir) {
+			layers = append(layers, tmplDir)
+		}
+	}
+
+	if len(layers) < 1 {
+		err = fmt.Errorf(ErrNoTemplatesFound, strings.Join(tmplDirs, ", "))
 	}
 
 	if skelputil.IsBlank(outputDir) {
-		err = fmt.Errorf(ErrBlankOutputDir, tmplDir)
+		err = fmt.Errorf(ErrBlankOutputDir)
 	}
 
 	if err == nil && !skelputil.PathExists(outputDir) {
 		err = os.MkdirAll(outputDir, os.ModePerm)
 	}
 
-	if err == nil {
+	rendered := make(map[string]bool)
+	missing := []MissingKey{}
+
+	for _, tmplDir := range layers {
+		if err != nil {
+			break
+		}
+
 		err = filepath.Walk(tmplDir, func(curPath string, fi os.FileInfo, werr error) error {
 			var terr error
 			var relTarget string
@@ -55,25 +81,58 @@ func (we *WalkingExecutor) Execute(tmplDir, outputDir string, tmplData interface
 				relTarget, terr = we.calculateRelativeTarget(tmplDir, curPath, tmplData)
 			}
 
+			// keep going to find the other missing keys, skipping what can't be named
+			if mke, ok := terr.(*MissingKeysError); ok {
+				missing = append(missing, mke.Keys...)
+
+				if fi.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			// rendered names come from user data and must stay inside the output directory
+			if terr == nil {
+				relTemplate, _ := filepath.Rel(tmplDir, curPath)
+				terr = checkTarget(outputDir, relTemplate, relTarget)
+			}
+
 			if terr == nil {
 				if fi.IsDir() {
 					return skelputil.MkdirAll(filepath.Join(outputDir, relTarget))
 				}
 
-				terr = we.processFileTemplate(outputDir, relTarget, curPath, tmplData, owProvider)
+				// a more specific layer already rendered this file
+				if rendered[relTarget] {
+					return nil
+				}
+
+				rendered[relTarget] = true
+				terr = we.processFileTemplate(outputDir, relTarget, tmplDir, curPath, tmplData, owProvider)
+			}
+
+			if mke, ok := terr.(*MissingKeysError); ok {
+				missing = append(missing, mke.Keys...)
+				terr = nil
 			}
 
 			return terr
 		})
 	}
 
+	if err == nil && len(missing) > 0 {
+		err = &MissingKeysError{Keys: missing}
+	}
+
 	return err
 }
 
-func (we *WalkingExecutor) processFileTemplate(outputDir, relTarget, templatePath string, tmplData interface{}, owProvider provider.OverwriteProvider) error {
+func (we *WalkingExecutor) processFileTemplate(outputDir, relTarget, tmplDir, templatePath string, tmplData interface{}, owProvider provider.OverwriteProvider) error {
 	var err error
 	var fileTemplate *template.Template
-	var destFile *os.File
+	var relTemplatePath string
+	var contents []byte
 	var srcMode os.FileMode
 
 	absTarget := filepath.Join(outputDir, relTarget)
@@ -83,24 +142,28 @@ func (we *WalkingExecutor) processFileTemplate(outputDir, relTarget, templatePat
 		return nil
 	}
 
-	fileTemplate, err = template.ParseFiles(templatePath)
+	// functions have to be known before the file is parsed
+	fileTemplate, err = template.New(filepath.Base(templatePath)).Option(we.tOptions...).Funcs(we.funcMap).ParseFiles(templatePath)
 
 	if err == nil {
-		fileTemplate.Option(we.tOptions...).Funcs(we.funcMap)
-		destFile, err = os.Create(absTarget)
+		relTemplatePath, err = filepath.Rel(tmplDir, templatePath)
 	}
 
+	// the file is only written once the template has run
 	if err == nil {
-		err = fileTemplate.Execute(destFile, tmplData)
+		contents, err = we.execute(fileTemplate, relTemplatePath, tmplData)
 	}
 
 	if err == nil {
 		srcMode, err = skelputil.GetFileMode(templatePath)
 	}
 
+	if err == nil {
+		err = ioutil.WriteFile(absTarget, contents, srcMode)
+	}
+
 	if err == nil {
 		os.Chmod(absTarget, srcMode)
-		err = destFile.Close()
 	}
 
 	return err
@@ -111,7 +174,7 @@ func (we *WalkingExecutor) calculateRelativeTarget(tmplDir, curPath string, tmpl
 	var relTmplFile string
 	var target string
 	var fnameTmpl *template.Template
-	var b bytes.Buffer
+	var b []byte
 
 	relTmplFile, err = filepath.Rel(tmplDir, curPath)
 
@@ -124,11 +187,11 @@ func (we *WalkingExecutor) calculateRelativeTarget(tmplDir, curPath string, tmpl
 	}
 
 	if err == nil {
-		err = fnameTmpl.Execute(&b, tmplData)
+		b, err = we.execute(fnameTmpl, relTmplFile, tmplData)
 	}
 
 	if err == nil {
-		target = b.String()
+		target = string(b)
 	}
 
 	return target, err
@@ -1,7 +1,9 @@
 package executor
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -43,3 +45,160 @@ func TestOutputDirCreated(t *testing.T) {
 		t.Errorf("ouput path (%) should have been created", outputPath)
 	}
 }
+
+func TestLayeredTemplatesFirstLayerWins(t *testing.T) {
+	outputPath, _ := ioutil.TempDir("", "skelp-layers-test")
+	defer os.RemoveAll(outputPath)
+
+	data := map[string]interface{}{"projectName": "layers", "license": "MIT", "owner": "brainicorn"}
+	layers := []string{"../testdata/generator/extends/child/templates", "../testdata/generator/extends/base/templates"}
+
+	exec := New(nil, []string{})
+	err := exec.ExecuteLayers(layers, outputPath, data, provider.DefaultOverwriteProvider)
+
+	if err != nil {
+		t.Fatalf("execution error: %s", err)
+	}
+
+	license, _ := ioutil.ReadFile(filepath.Join(outputPath, "LICENSE.txt"))
+	if string(license) != "MIT by brainicorn" {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(license), "MIT by brainicorn")
+	}
+
+	if !skelputil.PathExists(filepath.Join(outputPath, "README.md")) {
+		t.Errorf("file from the base layer should have been created")
+	}
+}
+
+func TestSkelpFuncsInFilenames(t *testing.T) {
+	templatesPath, _ := ioutil.TempDir("", "skelp-funcs-templates")
+	defer os.RemoveAll(templatesPath)
+
+	outputPath, _ := ioutil.TempDir("", "skelp-funcs-test")
+	defer os.RemoveAll(outputPath)
+
+	ioutil.WriteFile(filepath.Join(templatesPath, "{{.projectName | kebabCase}}.md"), []byte("{{.projectName | screamingSnakeCase}}"), 0644)
+
+	exec := New(skelputil.FunctionMap(), skelputil.TemplateOptions())
+	err := exec.Execute(templatesPath, outputPath, map[string]interface{}{"projectName": "Hoppy Brew"}, provider.DefaultOverwriteProvider)
+
+	if err != nil {
+		t.Fatalf("execution error: %s", err)
+	}
+
+	contents, _ := ioutil.ReadFile(filepath.Join(outputPath, "hoppy-brew.md"))
+	if string(contents) != "HOPPY_BREW" {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(contents), "HOPPY_BREW")
+	}
+}
+
+func TestStrictMissingKeyInRange(t *testing.T) {
+	templatesPath, _ := ioutil.TempDir("", "skelp-strict-templates")
+	defer os.RemoveAll(templatesPath)
+
+	outputPath, _ := ioutil.TempDir("", "skelp-strict-test")
+	defer os.RemoveAll(outputPath)
+
+	ioutil.WriteFile(filepath.Join(templatesPath, "taps.md"), []byte("{{range .taps}}\n{{.abvv}}{{end}}{{.styel}}"), 0644)
+
+	data := map[string]interface{}{"taps": []interface{}{map[string]interface{}{"abv": 5}}}
+
+	exec := New(nil, skelputil.StrictTemplateOptions())
+	err := exec.Execute(templatesPath, outputPath, data, provider.DefaultOverwriteProvider)
+
+	mke, ok := err.(*MissingKeysError)
+	if !ok {
+		t.Fatalf("wrong error type: %v", err)
+	}
+
+	if len(mke.Keys) != 1 || mke.Keys[0].String() != `taps.md:2: missing key "abvv"` {
+		t.Errorf("wrong missing keys: %v", mke.Keys)
+	}
+}
+
+var traversalTests = []struct {
+	filename string
+	dir      string
+	escapes  bool
+}{
+	{"{{.dir}}/evil.txt", "../../etc", true},
+	{"{{.dir}}/evil.txt", "/tmp/skelp-evil", true},
+	{"{{.dir}}", "..", true},
+	{`{{"..\x2fevil.txt"}}`, "", true},
+	{"{{.dir}}/evil.txt", "link", true},
+	{"{{.dir}}/evil.txt", "link/..", false},
+	{"{{.dir}}/fine.txt", "sub/../other", false},
+	{"{{.dir}}/fine.txt", "sub/deeper", false},
+}
+
+func TestPathTraversal(t *testing.T) {
+	for i, tt := range traversalTests {
+		templatesPath, _ := ioutil.TempDir("", "skelp-traversal-templates")
+		defer os.RemoveAll(templatesPath)
+
+		outputPath, _ := ioutil.TempDir("", "skelp-traversal-test")
+		defer os.RemoveAll(outputPath)
+
+		elsewhere, _ := ioutil.TempDir("", "skelp-traversal-elsewhere")
+		defer os.RemoveAll(elsewhere)
+
+		os.Symlink(elsewhere, filepath.Join(outputPath, "link"))
+		os.MkdirAll(filepath.Join(templatesPath, filepath.Dir(tt.filename)), os.ModePerm)
+		ioutil.WriteFile(filepath.Join(templatesPath, tt.filename), []byte("gotcha"), 0644)
+
+		exec := New(skelputil.FunctionMap(), skelputil.TemplateOptions())
+		err := exec.Execute(templatesPath, outputPath, map[string]interface{}{"dir": tt.dir}, provider.AlwaysOverwriteProvider)
+
+		if tt.escapes && (err == nil || !strings.Contains(err.Error(), "outside of the output directory")) {
+			t.Errorf("%d: expected an error for (%s) with (%s), have (%v)", i, tt.filename, tt.dir, err)
+		}
+
+		if !tt.escapes && err != nil {
+			t.Errorf("%d: unexpected error for (%s) with (%s): %s", i, tt.filename, tt.dir, err)
+		}
+
+		if files, _ := ioutil.ReadDir(elsewhere); len(files) > 0 {
+			t.Errorf("%d: a file was written through the symlink", i)
+		}
+	}
+}
+
+var includeDirTests = []struct {
+	outputDir string
+	dir       string
+	escapes   bool
+}{
+	{"", "../escape", true},
+	{"", "sub/../../escape", true},
+	{"", "sub/../other", true},
+	{"", "/tmp/skelp-evil", true},
+	{"", "link", true},
+	{"", "link/deeper", true},
+	{"", "sub/deeper", false},
+	{"", "", false},
+	{"not-yet", "sub", false},
+	{"link", "sub", false},
+	{"not-yet", "../escape", true},
+}
+
+func TestCheckIncludeDir(t *testing.T) {
+	outputPath, _ := ioutil.TempDir("", "skelp-include-test")
+	defer os.RemoveAll(outputPath)
+
+	elsewhere, _ := ioutil.TempDir("", "skelp-include-elsewhere")
+	defer os.RemoveAll(elsewhere)
+
+	os.Symlink(elsewhere, filepath.Join(outputPath, "link"))
+
+	for i, tt := range includeDirTests {
+		err := CheckIncludeDir(filepath.Join(outputPath, tt.outputDir), "../license", tt.dir)
+
+		if tt.escapes && (err == nil || !strings.Contains(err.Error(), "outside of the output directory")) {
+			t.Errorf("%d: expected an error for (%s) in (%s), have (%v)", i, tt.dir, tt.outputDir, err)
+		}
+
+		if !tt.escapes && err != nil {
+			t.Errorf("%d: unexpected error for (%s) in (%s): %s", i, tt.dir, tt.outputDir, err)
+		}
+	}
+}
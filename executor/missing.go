@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const (
+	ErrMissingKeys = "missing keys in strict mode:\n%s"
+
+	// a strict template stops at the first missing key, so the key is added and the template run again
+	// to find the next one. maxMissingKeys stops templates that never get past a key.
+	maxMissingKeys = 100
+)
+
+// MissingKey is a key used by a template that isn't in the data.
+type MissingKey struct {
+	File string
+	Line int
+	Key  string
+}
+
+func (mk MissingKey) String() string {
+	return fmt.Sprintf("%s:%d: missing key %q", mk.File, mk.Line, mk.Key)
+}
+
+// MissingKeysError lists every missing key found while executing strict templates.
+type MissingKeysError struct {
+	Keys []MissingKey
+}
+
+func (mke *MissingKeysError) Error() string {
+	lines := []string{}
+	for _, mk := range mke.Keys {
+		lines = append(lines, "  "+mk.String())
+	}
+
+	return fmt.Sprintf(ErrMissingKeys, strings.Join(lines, "\n"))
+}
+
+// execute runs the template against data. With strict options every missing key that can be found
+// is returned in a MissingKeysError, reported against file.
+func (we *WalkingExecutor) execute(tmpl *template.Template, file string, data interface{}) ([]byte, error) {
+	var out bytes.Buffer
+
+	err := tmpl.Execute(&out, data)
+
+	if err == nil || !we.strict {
+		return out.Bytes(), err
+	}
+
+	missing := []MissingKey{}
+	seen := make(map[string]bool)
+
+	for err != nil && len(missing) < maxMissingKeys {
+		line, chain, key, isMissingKey := skelputil.ParseMissingKey(err)
+
+		// later errors can come from the zero values standing in for missing keys
+		if !isMissingKey && len(missing) > 0 {
+			break
+		}
+
+		if !isMissingKey {
+			return nil, err
+		}
+
+		mk := MissingKey{File: file, Line: line, Key: key}
+
+		if seen[mk.String()] {
+			break
+		}
+
+		seen[mk.String()] = true
+		missing = append(missing, mk)
+
+		var patched bool
+		if data, patched = withKey(data, chain); !patched {
+			break
+		}
+
+		out.Reset()
+		err = tmpl.Execute(&out, data)
+	}
+
+	return nil, &MissingKeysError{Keys: missing}
+}
+
+// withKey returns a copy of data with an empty value at the field chain of a template node like
+// .owner.email or $.owner.email. Maps along the chain are copied so the caller's data isn't changed.
+func withKey(data interface{}, chain string) (interface{}, bool) {
+	if !strings.HasPrefix(chain, ".") && !strings.HasPrefix(chain, "$.") {
+		return data, false
+	}
+
+	fields := strings.Split(strings.TrimPrefix(strings.TrimPrefix(chain, "$"), "."), ".")
+
+	return withFields(data, fields)
+}
+
+func withFields(data interface{}, fields []string) (interface{}, bool) {
+	m, isMap := data.(map[string]interface{})
+
+	if !isMap {
+		return data, false
+	}
+
+	patched := make(map[string]interface{})
+	for k, v := range m {
+		patched[k] = v
+	}
+
+	if len(fields) == 1 {
+		patched[fields[0]] = ""
+		return patched, true
+	}
+
+	next, exists := m[fields[0]]
+
+	if !exists {
+		next = map[string]interface{}{}
+	}
+
+	child, ok := withFields(next, fields[1:])
+	patched[fields[0]] = child
+
+	return patched, ok
+}
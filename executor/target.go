@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ErrTargetOutsideOutput = "%s renders to %q which is outside of the output directory %s"
+	ErrIncludeDirOutside   = "include %s has dir %q which is outside of the output directory %s"
+)
+
+// CheckIncludeDir returns an error when the rendered dir of an included template isn't a directory
+// inside outputDir. Dirs can't be absolute, use .. or go through a symlink that points elsewhere.
+func CheckIncludeDir(outputDir, templateID, dir string) error {
+	for _, elem := range strings.Split(filepath.ToSlash(dir), "/") {
+		if elem == ".." {
+			return fmt.Errorf(ErrIncludeDirOutside, templateID, dir, outputDir)
+		}
+	}
+
+	isOutside, err := outside(outputDir, dir)
+
+	if err == nil && isOutside {
+		err = fmt.Errorf(ErrIncludeDirOutside, templateID, dir, outputDir)
+	}
+
+	return err
+}
+
+// checkTarget returns an error when a rendered target resolves outside of outputDir, either because
+// it's absolute, climbs out with .. or goes through a symlink that points elsewhere.
+func checkTarget(outputDir, relTemplate, relTarget string) error {
+	isOutside, err := outside(outputDir, relTarget)
+
+	if err == nil && isOutside {
+		err = fmt.Errorf(ErrTargetOutsideOutput, relTemplate, relTarget, outputDir)
+	}
+
+	return err
+}
+
+// outside reports whether rel resolves to a path outside of dir.
+func outside(dir, rel string) (bool, error) {
+	var err error
+	var realDir, realPath, realRel string
+
+	if filepath.IsAbs(rel) || filepath.VolumeName(rel) != "" || climbsOut(filepath.Clean(rel)) {
+		return true, nil
+	}
+
+	// the output directory of an include may not exist yet
+	realDir, err = resolveExisting(dir)
+
+	if err == nil {
+		realPath, err = resolveExisting(filepath.Join(dir, rel))
+	}
+
+	if err == nil {
+		realRel, err = filepath.Rel(realDir, realPath)
+	}
+
+	return err == nil && climbsOut(realRel), err
+}
+
+// resolveExisting follows the symlinks of the part of path that exists.
+func resolveExisting(path string) (string, error) {
+	missing := ""
+
+	for {
+		if _, err := os.Lstat(path); err == nil {
+			real, err := filepath.EvalSymlinks(path)
+			return filepath.Join(real, missing), err
+		}
+
+		parent := filepath.Dir(path)
+
+		if parent == path {
+			return filepath.Join(path, missing), nil
+		}
+
+		missing = filepath.Join(filepath.Base(path), missing)
+		path = parent
+	}
+}
+
+func climbsOut(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/brainicorn/skelp/executor"
 	"github.com/brainicorn/skelp/provider"
@@ -18,66 +19,232 @@ const (
 	ErrTemplateRootNotFound      = "Template root not found %s"
 	ErrSkelpTemplatesDirNotFound = "Skelp templates dir not found %s"
 	ErrCacheNotFoundNoDownload   = "Cached template not found and downloads are turned off: %s"
+	ErrIncludeCycle              = "Template include cycle detected: %s"
+	ErrExtendsCycle              = "Template extends cycle detected: %s"
 )
 
+// generation is a template whose data has been gathered, ready to be executed into out.
+type generation struct {
+	templateDirs []string
+	out          string
+	tmplData     interface{}
+	tOptions     []string
+}
+
 func (sg *SkelpGenerator) Generate(templateID string, dataProvider provider.DataProvider) error {
 	var err error
+	var localTemplatePath string
+	var out string
+	var generations []generation
+
+	err = skelputil.CheckFuncs(sg.skelpOptions.Funcs)
+
+	if err == nil && sg.skelpOptions.FuncsReceiver != nil && len(sg.skelpOptions.Funcs) > 0 {
+		err = sg.skelpOptions.FuncsReceiver(sg.skelpOptions.Funcs)
+	}
+
+	if err == nil {
+		localTemplatePath, err = sg.LocalTemplatePath(templateID)
+	}
+
+	if err == nil {
+		out = sg.skelpOptions.OutputDir
+
+		if skelputil.IsBlank(out) {
+			out, err = os.Getwd()
+		}
+	}
+
+	// every answer is gathered before any file is written so a bad answer for an include
+	// doesn't leave a half generated tree
+	if err == nil {
+		generations, err = sg.gatherGenerations(localTemplatePath, dataProvider, out, []string{})
+	}
+
+	for _, g := range generations {
+		if err != nil {
+			break
+		}
+
+		skelpExec := executor.New(sg.funcMap, g.tOptions)
+		err = skelpExec.ExecuteLayers(g.templateDirs, g.out, g.tmplData, sg.skelpOptions.OverwriteProvider)
+	}
+
+	return err
+}
+
+// LocalTemplatePath returns the path of the template on the local filesystem.
+// Aliases are looked up and repositories are downloaded/updated in the cache as needed.
+func (sg *SkelpGenerator) LocalTemplatePath(templateID string) (string, error) {
+	var err error
+	var localTemplatePath string
 
 	if skelputil.IsBlank(templateID) {
-		return fmt.Errorf(ErrBlankTemplateID)
+		return "", fmt.Errorf(ErrBlankTemplateID)
 	}
 
 	switch TypeForTemplateID(templateID) {
 	case TIDTypeAlias:
-		err = sg.aliasGeneration(templateID, dataProvider)
+		localTemplatePath, err = sg.aliasTemplatePath(templateID)
 	case TIDTypeFile:
-		err = sg.pathGeneration(templateID, dataProvider)
+		localTemplatePath = templateID
 	case TIDTypeRepo:
-		err = sg.repoGeneration(templateID, dataProvider)
+		localTemplatePath, err = sg.repoTemplatePath(templateID)
 	}
 
-	return err
+	return localTemplatePath, err
 }
 
-func (sg *SkelpGenerator) pathGeneration(rootTemplateDir string, dataProvider provider.DataProvider) error {
+// gatherGenerations gathers the data of the template at rootTemplateDir and of the templates it
+// includes, in the order they are generated. chain holds the templates that include this one.
+func (sg *SkelpGenerator) gatherGenerations(rootTemplateDir string, dataProvider provider.DataProvider, out string, chain []string) ([]generation, error) {
 	var err error
 	var absRootTemplateDir string
-	var skelpTemplatespath string
-	var out string
+	var templateDirs []string
 	var tmplData interface{}
+	var includes []provider.Include
 
 	absRootTemplateDir, err = filepath.Abs(rootTemplateDir)
 
-	if err == nil {
-		out = sg.skelpOptions.OutputDir
-		skelpTemplatespath = filepath.Join(absRootTemplateDir, skelpTemplatesDirname)
+	if err == nil && !skelputil.PathExists(absRootTemplateDir) {
+		err = fmt.Errorf(ErrTemplateRootNotFound, absRootTemplateDir)
+	}
 
-		if !skelputil.PathExists(absRootTemplateDir) {
-			err = fmt.Errorf(ErrTemplateRootNotFound, absRootTemplateDir)
-		}
+	if err == nil {
+		templateDirs, err = sg.TemplateLayers(absRootTemplateDir)
 	}
 
-	if err == nil && !skelputil.PathExists(skelpTemplatespath) {
-		err = fmt.Errorf(ErrSkelpTemplatesDirNotFound, skelpTemplatespath)
+	if err == nil && len(templateDirs) < 1 {
+		err = fmt.Errorf(ErrSkelpTemplatesDirNotFound, filepath.Join(absRootTemplateDir, skelpTemplatesDirname))
 	}
 
-	if err == nil && skelputil.IsBlank(out) {
-		out, err = os.Getwd()
+	if err == nil {
+		for _, root := range chain {
+			if root == absRootTemplateDir {
+				err = fmt.Errorf(ErrIncludeCycle, strings.Join(append(chain, absRootTemplateDir), " -> "))
+			}
+		}
 	}
 
 	if err == nil {
 		tmplData, err = dataProvider(absRootTemplateDir)
 	}
 
-	if err == nil {
-		skelpExec := executor.New(sg.funcMap, sg.tOptions)
-		err = skelpExec.Execute(skelpTemplatespath, out, tmplData, sg.skelpOptions.OverwriteProvider)
+	tOptions := sg.tOptions
+	strict := sg.skelpOptions.Strict
+
+	if err == nil && !strict && sg.skelpOptions.StrictProvider != nil {
+		strict, err = sg.skelpOptions.StrictProvider(absRootTemplateDir)
 	}
 
-	return err
+	if strict {
+		tOptions = skelputil.StrictTemplateOptions()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	generations := []generation{{templateDirs: templateDirs, out: out, tmplData: tmplData, tOptions: tOptions}}
+
+	if sg.skelpOptions.IncludesProvider != nil {
+		includes, err = sg.skelpOptions.IncludesProvider(absRootTemplateDir)
+	}
+
+	incChain := append(append([]string{}, chain...), absRootTemplateDir)
+
+	for _, inc := range includes {
+		var incPath string
+		var incGenerations []generation
+
+		if err == nil {
+			err = executor.CheckIncludeDir(out, inc.TemplateID, inc.Dir)
+		}
+
+		if err == nil {
+			incPath, err = sg.IncludeTemplatePath(absRootTemplateDir, inc.TemplateID)
+		}
+
+		if err == nil {
+			incGenerations, err = sg.gatherGenerations(incPath, inc.DataProvider, filepath.Join(out, inc.Dir), incChain)
+		}
+
+		generations = append(generations, incGenerations...)
+	}
+
+	return generations, err
 }
 
-func (sg *SkelpGenerator) repoGeneration(templateID string, dataProvider provider.DataProvider) error {
+// TemplateLayers returns the templates dirs of the template at absRootTemplateDir followed by the
+// templates dirs of the templates it extends, closest parent first.
+func (sg *SkelpGenerator) TemplateLayers(absRootTemplateDir string) ([]string, error) {
+	var err error
+	var parent string
+
+	layers := []string{}
+	chain := []string{}
+	root := absRootTemplateDir
+
+	for err == nil && !skelputil.IsBlank(root) {
+		chain = append(chain, root)
+
+		tmplDir := filepath.Join(root, skelpTemplatesDirname)
+		if skelputil.PathExists(tmplDir) {
+			layers = append(layers, tmplDir)
+		}
+
+		if sg.skelpOptions.ParentProvider == nil {
+			break
+		}
+
+		parent, err = sg.skelpOptions.ParentProvider(root, sg.relativeTemplateResolver(root))
+
+		if err == nil && !skelputil.IsBlank(parent) {
+			parent, err = filepath.Abs(parent)
+		}
+
+		if err == nil && !skelputil.IsBlank(parent) {
+			for _, r := range chain {
+				if r == parent {
+					err = fmt.Errorf(ErrExtendsCycle, strings.Join(append(chain, parent), " -> "))
+				}
+			}
+
+			if err == nil && !skelputil.PathExists(parent) {
+				err = fmt.Errorf(ErrTemplateRootNotFound, parent)
+			}
+		}
+
+		root = parent
+	}
+
+	return layers, err
+}
+
+// relativeTemplateResolver returns a resolver for template ids found in the template at
+// absRootTemplateDir.
+func (sg *SkelpGenerator) relativeTemplateResolver(absRootTemplateDir string) provider.TemplateResolver {
+	return func(templateID string) (string, error) {
+		return sg.LocalTemplatePath(relativeTemplateID(absRootTemplateDir, templateID))
+	}
+}
+
+// IncludeTemplatePath returns the local path of a template included by the template at
+// absRootTemplateDir, downloading it if needed.
+func (sg *SkelpGenerator) IncludeTemplatePath(absRootTemplateDir, templateID string) (string, error) {
+	return sg.relativeTemplateResolver(absRootTemplateDir)(templateID)
+}
+
+// relativeTemplateID resolves relative file paths against the template that refers to them.
+func relativeTemplateID(absRootTemplateDir, templateID string) string {
+	if TypeForTemplateID(templateID) == TIDTypeFile && !filepath.IsAbs(templateID) {
+		return filepath.Join(absRootTemplateDir, templateID)
+	}
+
+	return templateID
+}
+
+func (sg *SkelpGenerator) repoTemplatePath(templateID string) (string, error) {
 	var err error
 	var localTemplatePath string
 
@@ -99,11 +266,7 @@ func (sg *SkelpGenerator) repoGeneration(templateID string, dataProvider provide
 		err = sg.checkForUpdates(templateID, localTemplatePath)
 	}
 
-	if err == nil {
-		err = sg.pathGeneration(localTemplatePath, dataProvider)
-	}
-
-	return err
+	return localTemplatePath, err
 }
 
 func (sg *SkelpGenerator) doDownload(u, path string) error {
@@ -172,15 +335,16 @@ func (sg *SkelpGenerator) checkForUpdates(u, path string) error {
 
 }
 
-func (sg *SkelpGenerator) aliasGeneration(templateID string, dataProvider provider.DataProvider) error {
+func (sg *SkelpGenerator) aliasTemplatePath(templateID string) (string, error) {
 	var err error
 	var aliasedTemplateID string
+	var localTemplatePath string
 
 	aliasedTemplateID, err = sg.IDForAlias(templateID)
 
 	if err == nil {
-		err = sg.Generate(aliasedTemplateID, dataProvider)
+		localTemplatePath, err = sg.LocalTemplatePath(aliasedTemplateID)
 	}
 
-	return err
+	return localTemplatePath, err
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/brainicorn/skelp/provider"
 	"github.com/brainicorn/skelp/skelplate"
+	"github.com/brainicorn/skelp/skelputil"
 )
 
 var (
@@ -342,3 +343,390 @@ func TestOverwrite(t *testing.T) {
 		t.Errorf("contents don't match, have (%s), want (%s)", string(newReadme), newReadmeExpectedLocal)
 	}
 }
+
+func TestLocalGenIncludes(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	defData := map[string]interface{}{"projectName": projectNameLocal}
+	dp := skelplate.NewDataProvider(defData)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.IncludesProvider = dp.IncludesProviderFunc
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/includes/service", dp.DataProviderFunc)
+
+	if err != nil {
+		t.Errorf("generation error: %s", err)
+	}
+
+	readmePath := filepath.Join(tmpDir, readmeFmtLocal)
+	licensePath := filepath.Join(tmpDir, projectNameLocal, "LICENSE")
+
+	readme, err := ioutil.ReadFile(readmePath)
+
+	if err != nil {
+		t.Errorf("can't open out file (%s): %s", readmePath, err)
+	}
+
+	license, err := ioutil.ReadFile(licensePath)
+
+	if err != nil {
+		t.Errorf("can't open out file (%s): %s", licensePath, err)
+	}
+
+	if string(readme) != "## "+projectNameLocal {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(readme), "## "+projectNameLocal)
+	}
+
+	licenseExpected := "Copyright 2017 " + strings.ToUpper(projectNameLocal)
+	if string(license) != licenseExpected {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(license), licenseExpected)
+	}
+}
+
+func TestLocalGenIncludeDataMapping(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	defData := map[string]interface{}{"projectName": projectNameLocal, "holder": "someone else"}
+	dp := skelplate.NewDataProvider(defData)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.IncludesProvider = dp.IncludesProviderFunc
+
+	err := New(opts).Generate("../testdata/generator/includes/service", dp.DataProviderFunc)
+
+	if err != nil {
+		t.Fatalf("generation error: %s", err)
+	}
+
+	licensePath := filepath.Join(tmpDir, projectNameLocal, "LICENSE")
+	license, err := ioutil.ReadFile(licensePath)
+
+	licenseExpected := "Copyright 2017 " + strings.ToUpper(projectNameLocal)
+	if err != nil || string(license) != licenseExpected {
+		t.Errorf("contents don't match, have (%s, %v), want (%s)", string(license), err, licenseExpected)
+	}
+}
+
+func TestLocalGenIncludeCycle(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	dp := skelplate.NewDataProvider(nil)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.IncludesProvider = dp.IncludesProviderFunc
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/includes/cycle-a", dp.DataProviderFunc)
+
+	if err == nil || !strings.HasPrefix(err.Error(), "Template include cycle detected") {
+		t.Errorf("wrong error: have (%s), want (%s)", err, "Template include cycle detected")
+	}
+}
+
+func TestLocalGenIncludeDirOutside(t *testing.T) {
+	var tests = []string{"../escape", "a/../../escape", "/tmp/escape", "a/../b"}
+
+	for i, projectName := range tests {
+		tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+		defer os.RemoveAll(tmpDir)
+
+		dp := skelplate.NewDataProvider(map[string]interface{}{"projectName": projectName})
+		dp.SetOutputDir(tmpDir)
+
+		opts := DefaultOptions()
+		opts.OutputDir = tmpDir
+		opts.IncludesProvider = dp.IncludesProviderFunc
+
+		gen := New(opts)
+
+		err := gen.Generate("../testdata/generator/includes/service", dp.DataProviderFunc)
+
+		if err == nil || !strings.Contains(err.Error(), "outside of the output directory") {
+			t.Errorf("%d: wrong error: have (%v), want (%s)", i, err, "outside of the output directory")
+		}
+
+		if skelputil.PathExists(filepath.Join(tmpDir, "..", "escape")) {
+			t.Errorf("%d: include was generated outside of %s", i, tmpDir)
+		}
+	}
+}
+
+func TestLocalGenIncludesProviderDirOutside(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	outDir := filepath.Join(tmpDir, "out")
+	dp := skelplate.NewDataProvider(map[string]interface{}{"projectName": projectNameLocal, "holder": "brainicorn"})
+
+	opts := DefaultOptions()
+	opts.OutputDir = outDir
+	opts.IncludesProvider = func(templateRoot string) ([]provider.Include, error) {
+		return []provider.Include{{TemplateID: "../license", Dir: "../escape", DataProvider: dp.DataProviderFunc}}, nil
+	}
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/includes/service", dp.DataProviderFunc)
+
+	if err == nil || !strings.Contains(err.Error(), "outside of the output directory") {
+		t.Errorf("wrong error: have (%v), want (%s)", err, "outside of the output directory")
+	}
+
+	if skelputil.PathExists(filepath.Join(tmpDir, "escape")) {
+		t.Errorf("include was generated outside of %s", outDir)
+	}
+}
+
+func TestLocalGenIncludeDataGatheredFirst(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	dp := skelplate.NewDataProvider(map[string]interface{}{"projectName": projectNameLocal})
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.IncludesProvider = func(templateRoot string) ([]provider.Include, error) {
+		badAnswer := func(templateRoot string) (interface{}, error) {
+			return nil, fmt.Errorf("bad answer")
+		}
+
+		return []provider.Include{{TemplateID: "../license", Dir: "license", DataProvider: badAnswer}}, nil
+	}
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/includes/service", dp.DataProviderFunc)
+
+	if err == nil || err.Error() != "bad answer" {
+		t.Errorf("wrong error: have (%v), want (%s)", err, "bad answer")
+	}
+
+	if skelputil.PathExists(filepath.Join(tmpDir, readmeFmtLocal)) {
+		t.Errorf("files were generated before the include data was gathered")
+	}
+
+	if gen.skelpOptions.OutputDir != tmpDir {
+		t.Errorf("output dir was changed: have (%s), want (%s)", gen.skelpOptions.OutputDir, tmpDir)
+	}
+}
+
+func TestLocalGenExtends(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	defData := map[string]interface{}{"projectName": projectNameLocal, "license": "GPL", "owner": "brainicorn"}
+	dp := skelplate.NewDataProvider(defData)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.ParentProvider = dp.ParentProviderFunc
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/extends/child", dp.DataProviderFunc)
+
+	if err != nil {
+		t.Errorf("generation error: %s", err)
+	}
+
+	readmePath := filepath.Join(tmpDir, readmeFmtLocal)
+	licensePath := filepath.Join(tmpDir, "LICENSE.txt")
+
+	readme, err := ioutil.ReadFile(readmePath)
+
+	if err != nil {
+		t.Errorf("can't open out file (%s): %s", readmePath, err)
+	}
+
+	license, err := ioutil.ReadFile(licensePath)
+
+	if err != nil {
+		t.Errorf("can't open out file (%s): %s", licensePath, err)
+	}
+
+	readmeExpected := "## " + projectNameLocal + " (GPL)"
+	if string(readme) != readmeExpected {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(readme), readmeExpected)
+	}
+
+	if string(license) != "GPL by brainicorn" {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(license), "GPL by brainicorn")
+	}
+}
+
+func TestLocalGenExtendsCycle(t *testing.T) {
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	dp := skelplate.NewDataProvider(nil)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.ParentProvider = dp.ParentProviderFunc
+
+	gen := New(opts)
+
+	err := gen.Generate("../testdata/generator/extends/cycle-x", dp.DataProviderFunc)
+
+	if err == nil || !strings.HasPrefix(err.Error(), "Template extends cycle detected") {
+		t.Errorf("wrong error: have (%s), want (%s)", err, "Template extends cycle detected")
+	}
+}
+
+func TestLocalGenFuncs(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	funcs := map[string]interface{}{
+		"brewify": func(s string) string { return s + "-brew" },
+		"upper":   func(s string) string { return "LOUD " + s },
+	}
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.Funcs = funcs
+
+	gen := New(opts)
+
+	dp := skelplate.NewDataProvider(map[string]interface{}{"projectName": "hops"})
+	dp.UseDefaults()
+
+	if err := dp.SetFuncs(funcs); err != nil {
+		t.Fatalf("error setting funcs: %s", err)
+	}
+
+	if err := gen.Generate("../testdata/generator/funcs", dp.DataProviderFunc); err != nil {
+		t.Fatalf("generation error: %s", err)
+	}
+
+	expected := "hops-brew LOUD hops"
+	contents, err := ioutil.ReadFile(filepath.Join(tmpDir, "hops-brew.md"))
+
+	if err != nil || string(contents) != expected {
+		t.Errorf("contents don't match, have (%s, %v), want (%s)", string(contents), err, expected)
+	}
+}
+
+func TestLocalGenFuncsReceiver(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	dp := skelplate.NewDataProvider(map[string]interface{}{"projectName": "hops"})
+	dp.UseDefaults()
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.Funcs = map[string]interface{}{"brewify": func(s string) string { return s + "-brew" }}
+	opts.FuncsReceiver = dp.SetFuncs
+
+	if err := New(opts).Generate("../testdata/generator/funcs", dp.DataProviderFunc); err != nil {
+		t.Fatalf("generation error: %s", err)
+	}
+
+	expected := "hops-brew HOPS"
+	contents, err := ioutil.ReadFile(filepath.Join(tmpDir, "hops-brew.md"))
+
+	if err != nil || string(contents) != expected {
+		t.Errorf("contents don't match, have (%s, %v), want (%s)", string(contents), err, expected)
+	}
+}
+
+func TestLocalGenInvalidFuncs(t *testing.T) {
+	funcs := map[string]interface{}{"brewify": "not a function"}
+
+	opts := DefaultOptions()
+	opts.Funcs = funcs
+
+	err := New(opts).Generate("../testdata/generator/funcs", skelplate.NewDataProvider(nil).DataProviderFunc)
+
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid template functions") {
+		t.Errorf("wrong error, have (%v)", err)
+	}
+
+	if err = skelplate.NewDataProvider(nil).SetFuncs(funcs); err == nil {
+		t.Error("data provider should reject invalid funcs")
+	}
+}
+
+func TestLocalGenStrict(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+
+	dp := skelplate.NewDataProvider(nil)
+	dp.UseDefaults()
+	opts.StrictProvider = dp.StrictProviderFunc
+
+	err := New(opts).Generate("../testdata/generator/strict", dp.DataProviderFunc)
+
+	expected := `missing keys in strict mode:
+  README.md:2: missing key "projectNmae"
+  README.md:3: missing key "emial"
+  {{.packgeName}}.go:1: missing key "packgeName"`
+
+	if err == nil || err.Error() != expected {
+		t.Fatalf("wrong error:\nhave (%v)\nwant (%s)", err, expected)
+	}
+
+	if skelputil.PathExists(filepath.Join(tmpDir, "README.md")) {
+		t.Error("file with missing keys should not be written")
+	}
+
+	contents, _ := ioutil.ReadFile(filepath.Join(tmpDir, "ok.md"))
+	if string(contents) != "strictgen by brainicorn@example.com" {
+		t.Errorf("contents don't match, have (%s), want (%s)", string(contents), "strictgen by brainicorn@example.com")
+	}
+}
+
+func TestLocalGenStrictOption(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+	opts.Strict = true
+
+	data := map[string]interface{}{"projectName": projectNameLocal, "packageName": packageNameLocal, "unused": "yes"}
+	dp := skelplate.NewDataProvider(data)
+
+	if err := New(opts).Generate("../testdata/generator/simple", dp.DataProviderFunc); err != nil {
+		t.Errorf("generation error: %s", err)
+	}
+}
+
+func TestLocalGenStrictDefault(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-localgen-test")
+	defer os.RemoveAll(tmpDir)
+
+	opts := DefaultOptions()
+	opts.OutputDir = tmpDir
+
+	dp := skelplate.NewDataProvider(nil)
+	dp.UseDefaults()
+
+	err := New(opts).Generate("../testdata/generator/strictdefault", dp.DataProviderFunc)
+
+	expected := `skelp.json:10: variable "repo": missing key "projectNmae"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("wrong error: have (%v) want (%s)", err, expected)
+	}
+}
@@ -26,6 +26,19 @@ type SkelpOptions struct {
 	SkelpDirOverride  string
 	OverwriteProvider provider.OverwriteProvider
 	BasicAuthProvider provider.BasicAuthProvider
+	IncludesProvider  provider.IncludesProvider
+	ParentProvider    provider.ParentProvider
+	StrictProvider    provider.StrictProvider
+	FuncsReceiver     provider.FuncsReceiver
+
+	// Strict makes template files and file names fail on keys missing from the data, as if every
+	// template's StrictProvider returned true.
+	Strict bool
+
+	// Funcs are added to the functions available to template files and file names. They take precedence
+	// over the skelp and sprig functions with the same names. They're given to FuncsReceiver so that
+	// descriptor defaults and names can use them too.
+	Funcs map[string]interface{}
 }
 
 func DefaultOptions() SkelpOptions {
@@ -51,7 +64,7 @@ type SkelpGenerator struct {
 func New(options SkelpOptions) *SkelpGenerator {
 	return &SkelpGenerator{
 		skelpOptions: options,
-		funcMap:      skelputil.FunctionMap(),
+		funcMap:      skelputil.FunctionMapWith(options.Funcs),
 		tOptions:     skelputil.TemplateOptions(),
 	}
 }
@@ -0,0 +1,18 @@
+package prompter
+
+// Header displays a title, and an optional description, before a group of prompts.
+// Only the Terminal of its Prompt is used.
+type Header struct {
+	Prompt
+	Title       string
+	Description string
+}
+
+var HeaderTemplate = `{{"\n"}}
+{{- color "cyan+hb"}}{{ .Title }}{{color "reset"}}{{"\n"}}
+{{- if .Description}}{{color "cyan"}}{{ .Description }}{{color "reset"}}{{"\n"}}{{end}}`
+
+// Show renders the header.
+func (h *Header) Show() error {
+	return h.terminal().Render(HeaderTemplate, h)
+}
@@ -1,11 +1,9 @@
 package prompter
 
 import (
-	"os"
 	"strings"
 
 	"github.com/AlecAivazis/survey/core"
-	"github.com/AlecAivazis/survey/terminal"
 )
 
 type KeyedInput struct {
@@ -74,8 +72,10 @@ func (i *KeyedInput) Ask() (string, error) {
 
 	i.yesNoValidatorIfNeeded()
 
+	term := i.terminal()
+
 	// render the template
-	err = i.Render(
+	err = term.Render(
 		KeyedInputTemplate,
 		KeyedTemplateData{
 			InputTemplateData: InputTemplateData{
@@ -87,24 +87,20 @@ func (i *KeyedInput) Ask() (string, error) {
 	)
 	if err == nil {
 
-		rr := terminal.NewRuneReader(os.Stdin)
-		rr.SetTermMode()
-		defer rr.RestoreTermMode()
+		term.Start()
+		defer term.Stop()
 
 		line := []rune{}
 		// get the next line
 		for {
 			if err == nil {
-				line, err = rr.ReadLine(mask)
+				line, err = term.ReadLine(mask)
 				if err == nil {
-					// terminal will echo the \n so we need to jump back up one row
-					terminal.CursorPreviousLine(1)
-
 					if string(line) == string(core.HelpInputRune) && i.Help != "" {
 						if i.BeforePrompt != nil {
 							i.BeforePrompt()
 						}
-						err = i.Render(
+						err = term.Render(
 							KeyedInputTemplate,
 							KeyedTemplateData{
 								InputTemplateData: InputTemplateData{
@@ -135,8 +131,8 @@ func (i *KeyedInput) Ask() (string, error) {
 			}
 
 			// wait for a valid response
-			for invalid := i.Validate(ans); invalid != nil; invalid = i.Validate(ans) {
-				err = i.Prompt.Error(invalid)
+			for invalid := i.Validate(ans); err == nil && invalid != nil; invalid = i.Validate(ans) {
+				err = term.Error(invalid)
 				// if there was a problem
 				if err == nil {
 					// ask for more input
@@ -145,7 +141,7 @@ func (i *KeyedInput) Ask() (string, error) {
 			}
 
 			if err == nil {
-				i.Render(
+				term.Render(
 					KeyedInputTemplate,
 					KeyedTemplateData{
 						InputTemplateData: InputTemplateData{
@@ -0,0 +1,192 @@
+package prompter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/AlecAivazis/survey/core"
+)
+
+// MultilineInput asks for text that spans several lines. The text is edited in Editor when one is
+// set, otherwise lines are read until a blank line is entered.
+type MultilineInput struct {
+	Prompt
+	// Editor is the command that edits the temp file holding the answer, e.g. "vim" or "code --wait".
+	Editor string
+}
+
+const (
+	msgMultilineEditor = "opening %s"
+	msgMultilineInline = "enter a blank line to finish"
+	msgMultilineLines  = "(%d lines)"
+
+	errEditorFailed = "error running editor %s: %s"
+)
+
+var MultilineInputTemplate = `
+{{- if .ShowHelp }}{{- color "cyan"}}{{ HelpIcon }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color "green+hb"}}{{ QuestionIcon }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Question }} {{color "reset"}}
+{{- if .ShowAnswer}}
+  {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else }}
+  {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ HelpInputRune }} for help]{{color "reset"}} {{end}}
+  {{- color "white"}}[{{ .Hint }}]{{color "reset"}}{{"\n"}}
+  {{- if and .Default (not .Editor) (not .Lines)}}{{color "white"}}{{ .Default }}{{color "reset"}}{{"\n"}}{{end}}
+  {{- range .Lines}}{{.}}{{"\n"}}{{end}}
+{{- end}}`
+
+type MultilineTemplateData struct {
+	InputTemplateData
+	Hint   string
+	Editor bool
+	// Lines are the lines entered so far.
+	Lines []string
+}
+
+// DefaultEditor returns the editor set by $VISUAL or $EDITOR, or an empty string when neither is set.
+func DefaultEditor() string {
+	if editor := strings.TrimSpace(os.Getenv("VISUAL")); editor != "" {
+		return editor
+	}
+
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+func (m *MultilineInput) Ask() (string, error) {
+	var err error
+	var ans string
+
+	if m.BeforePrompt != nil {
+		m.BeforePrompt()
+	}
+
+	term := m.terminal()
+
+	data := MultilineTemplateData{
+		InputTemplateData: InputTemplateData{Prompt: m.Prompt},
+		Hint:              msgMultilineInline,
+		Editor:            m.Editor != "",
+	}
+
+	if data.Editor {
+		data.Hint = fmt.Sprintf(msgMultilineEditor, m.Editor)
+	}
+
+	err = term.Render(MultilineInputTemplate, data)
+
+	if err == nil && data.Editor {
+		ans, err = m.edit()
+	} else if err == nil {
+		ans, err = m.readLines(term, &data)
+	}
+
+	// wait for a valid response
+	for invalid := m.Validate(ans); err == nil && invalid != nil; invalid = m.Validate(ans) {
+		err = term.Error(invalid)
+
+		if err == nil {
+			ans, err = m.Ask()
+		}
+	}
+
+	if err == nil {
+		data.Lines = nil
+		data.Answer = summarize(ans)
+		data.ShowAnswer = true
+		term.Render(MultilineInputTemplate, data)
+	}
+
+	return ans, err
+}
+
+// readLines reads lines from the terminal until a blank line. The default is used when the first line
+// is blank.
+func (m *MultilineInput) readLines(term Terminal, data *MultilineTemplateData) (string, error) {
+	lines := []string{}
+
+	term.Start()
+	defer term.Stop()
+
+	for {
+		line, err := term.ReadLine(0)
+
+		if err != nil {
+			return "", err
+		}
+
+		if len(lines) < 1 && string(line) == string(core.HelpInputRune) && m.Help != "" {
+			if m.BeforePrompt != nil {
+				m.BeforePrompt()
+			}
+
+			data.ShowHelp = true
+			term.Render(MultilineInputTemplate, data)
+			continue
+		}
+
+		if strings.TrimSpace(string(line)) == "" {
+			break
+		}
+
+		// the lines entered are rendered with the prompt so they're replaced with it
+		lines = append(lines, string(line))
+		data.Lines = lines
+		term.Render(MultilineInputTemplate, data)
+	}
+
+	if len(lines) < 1 {
+		return m.Default, nil
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// edit runs the editor on a temp file holding the default and returns the saved content without its
+// trailing newline.
+func (m *MultilineInput) edit() (string, error) {
+	var err error
+	var content []byte
+	var tmpFile *os.File
+
+	tmpFile, err = ioutil.TempFile("", "skelp-answer")
+
+	if err == nil {
+		defer os.Remove(tmpFile.Name())
+
+		_, err = tmpFile.WriteString(m.Default)
+		tmpFile.Close()
+	}
+
+	if err == nil {
+		args := strings.Fields(m.Editor)
+		cmd := exec.Command(args[0], append(args[1:], tmpFile.Name())...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if rerr := cmd.Run(); rerr != nil {
+			err = fmt.Errorf(errEditorFailed, m.Editor, rerr)
+		}
+	}
+
+	if err == nil {
+		content, err = ioutil.ReadFile(tmpFile.Name())
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), err
+}
+
+// summarize shortens a multi-line answer to its first line and line count for display.
+func summarize(ans string) string {
+	lines := strings.Split(ans, "\n")
+
+	if len(lines) < 2 {
+		return ans
+	}
+
+	return lines[0] + " " + fmt.Sprintf(msgMultilineLines, len(lines))
+}
@@ -0,0 +1,122 @@
+package prompter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/core"
+)
+
+var multilineTests = []struct {
+	ask      *MultilineInput
+	answers  []string
+	expected string
+}{
+	{&MultilineInput{Prompt: Prompt{Question: "intro?"}}, []string{"hoppy", "and bitter", ""}, "hoppy\nand bitter"},
+	{&MultilineInput{Prompt: Prompt{Question: "intro?", Default: "a fine ale"}}, []string{""}, "a fine ale"},
+	{&MultilineInput{Prompt: Prompt{Question: "intro?", Help: "a few lines"}}, []string{"?", "malty", ""}, "malty"},
+	{&MultilineInput{Prompt: Prompt{Question: "intro?", Validators: []Validator{StringNotBlank}}}, []string{"", "stout", ""}, "stout"},
+}
+
+func TestMultilineInline(t *testing.T) {
+	core.DisableColor = true
+
+	for i, test := range multilineTests {
+		term := NewScriptedTerminal(test.answers...)
+		test.ask.Terminal = term
+
+		ans, err := test.ask.Ask()
+
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		}
+
+		if ans != test.expected {
+			t.Errorf("%d: wrong answer, have (%q) want (%q)", i, ans, test.expected)
+		}
+
+		if term.Remaining() != 0 {
+			t.Errorf("%d: %d keystrokes were not read", i, term.Remaining())
+		}
+	}
+}
+
+func TestMultilineSummary(t *testing.T) {
+	core.DisableColor = true
+
+	term := NewScriptedTerminal("hoppy", "and bitter", "")
+	ask := &MultilineInput{Prompt: Prompt{Question: "intro?", Terminal: term}}
+
+	if _, err := ask.Ask(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, want := range []string{"[enter a blank line to finish]", "intro? hoppy (2 lines)\n"} {
+		if !strings.Contains(term.Output(), want) {
+			t.Errorf("output is missing (%q): %s", want, term.Output())
+		}
+	}
+}
+
+func TestMultilineEditor(t *testing.T) {
+	core.DisableColor = true
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-editor-test")
+	defer os.RemoveAll(tmpDir)
+
+	editor := filepath.Join(tmpDir, "editor.sh")
+	ioutil.WriteFile(editor, []byte("#!/bin/sh\necho ' and bitter' >> \"$1\"\n"), 0755)
+
+	term := NewScriptedTerminal()
+	ask := &MultilineInput{Prompt: Prompt{Question: "intro?", Default: "hoppy\n", Terminal: term}, Editor: editor}
+
+	ans, err := ask.Ask()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ans != "hoppy\n and bitter" {
+		t.Errorf("wrong answer, have (%q) want (%q)", ans, "hoppy\n and bitter")
+	}
+
+	if !strings.Contains(term.Output(), "[opening "+editor+"]") {
+		t.Errorf("output is missing the editor hint: %s", term.Output())
+	}
+}
+
+func TestMultilineEditorFails(t *testing.T) {
+	ask := &MultilineInput{Prompt: Prompt{Question: "intro?", Terminal: NewScriptedTerminal()}, Editor: "false"}
+
+	if _, err := ask.Ask(); err == nil || !strings.Contains(err.Error(), "error running editor false") {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestDefaultEditor(t *testing.T) {
+	visual, editor := os.Getenv("VISUAL"), os.Getenv("EDITOR")
+	defer os.Setenv("VISUAL", visual)
+	defer os.Setenv("EDITOR", editor)
+
+	var tests = []struct {
+		visual   string
+		editor   string
+		expected string
+	}{
+		{"code --wait", "vi", "code --wait"},
+		{"", "vi", "vi"},
+		{" ", "", ""},
+	}
+
+	for i, test := range tests {
+		os.Setenv("VISUAL", test.visual)
+		os.Setenv("EDITOR", test.editor)
+
+		if have := DefaultEditor(); have != test.expected {
+			t.Errorf("%d: wrong editor, have (%s) want (%s)", i, have, test.expected)
+		}
+	}
+}
@@ -1,8 +1,6 @@
 package prompter
 
 import (
-	"fmt"
-
 	"github.com/AlecAivazis/survey/core"
 )
 
@@ -19,12 +17,13 @@ type Prompt struct {
 	Help         string
 	Validators   []Validator
 	BeforePrompt func()
+	// Terminal is read from and rendered to instead of stdin and stdout when set.
+	Terminal Terminal
 }
 
 func (p *Prompt) Validate(val string) error {
 	var err error
 	for _, v := range p.Validators {
-		fmt.Println("calling validator")
 		err = v(val)
 
 		if err != nil {
@@ -0,0 +1,118 @@
+package prompter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/AlecAivazis/survey/terminal"
+)
+
+// ScriptedTerminal is a Terminal that plays back predefined keystrokes and records everything the
+// prompts render. It lets tests and automation drive prompts without a real terminal.
+// Reading past the end of the script returns io.EOF.
+type ScriptedTerminal struct {
+	keys   []rune
+	output bytes.Buffer
+}
+
+// NewScriptedTerminal creates a terminal that answers prompts in order. Each answer is typed
+// followed by enter. Answers can hold keystrokes like terminal.KeyArrowDown for selections.
+func NewScriptedTerminal(answers ...string) *ScriptedTerminal {
+	st := &ScriptedTerminal{}
+
+	for _, a := range answers {
+		st.Answer(a)
+	}
+
+	return st
+}
+
+// Answer adds keystrokes followed by enter to the script.
+func (st *ScriptedTerminal) Answer(keys string) {
+	st.Type(keys + "\n")
+}
+
+// Type adds keystrokes to the script.
+func (st *ScriptedTerminal) Type(keys string) {
+	st.keys = append(st.keys, []rune(keys)...)
+}
+
+// Remaining returns the number of keystrokes that haven't been read.
+func (st *ScriptedTerminal) Remaining() int {
+	return len(st.keys)
+}
+
+// Output returns everything rendered so far, including the echo of each line read.
+func (st *ScriptedTerminal) Output() string {
+	return st.output.String()
+}
+
+func (st *ScriptedTerminal) Start() error {
+	return nil
+}
+
+func (st *ScriptedTerminal) Stop() error {
+	return nil
+}
+
+func (st *ScriptedTerminal) Render(tmpl string, data interface{}) error {
+	out, err := core.RunTemplate(tmpl, data)
+
+	if err == nil {
+		st.output.WriteString(out)
+	}
+
+	return err
+}
+
+func (st *ScriptedTerminal) Error(invalid error) error {
+	_, err := fmt.Fprintf(&st.output, "%s\n", invalid)
+
+	return err
+}
+
+func (st *ScriptedTerminal) ReadLine(mask rune) ([]rune, error) {
+	line := []rune{}
+
+	for {
+		r, err := st.ReadKey()
+
+		if err != nil {
+			return line, err
+		}
+
+		switch r {
+		case '\r', '\n':
+			echo := string(line)
+			if mask != 0 {
+				echo = strings.Repeat(string(mask), len(line))
+			}
+
+			st.output.WriteString(echo + "\n")
+
+			return line, nil
+		case terminal.KeyInterrupt, terminal.KeyEndTransmission:
+			return line, ErrInterrupt
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		default:
+			line = append(line, r)
+		}
+	}
+}
+
+func (st *ScriptedTerminal) ReadKey() (rune, error) {
+	if len(st.keys) < 1 {
+		return 0, io.EOF
+	}
+
+	r := st.keys[0]
+	st.keys = st.keys[1:]
+
+	return r, nil
+}
@@ -0,0 +1,118 @@
+package prompter
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/AlecAivazis/survey/terminal"
+)
+
+var tmplScriptedTests = []struct {
+	ask      Prompter
+	answers  []string
+	expected string
+}{
+	{&KeyedInput{Prompt: Prompt{Question: "name?"}}, []string{"beer"}, "beer"},
+	{&KeyedInput{Prompt: Prompt{Question: "name?", Default: "ipa"}}, []string{""}, "ipa"},
+	{&KeyedInput{Prompt: Prompt{Question: "name?"}}, []string{"bet\x7fer"}, "beer"},
+	{&KeyedInput{Prompt: Prompt{Question: "hoppy?", Default: "n"}, IsConfirm: true}, []string{"y"}, "true"},
+	{&KeyedInput{Prompt: Prompt{Question: "hoppy?"}, IsConfirm: true}, []string{"maybe", "n"}, "false"},
+	{&KeyedInput{Prompt: Prompt{Question: "abv?", Validators: []Validator{IsANumber}}}, []string{"strong", "7"}, "7"},
+	{&SelectedInput{Prompt: Prompt{Question: "style?"}, Options: []string{"ipa", "stout", "lager"}}, []string{"\x0e\x0e"}, "lager"},
+	{&SelectedInput{Prompt: Prompt{Question: "styles?"}, Options: []string{"ipa", "stout", "lager"}, IsMulti: true}, []string{" \x0e\x0e "}, "ipa,lager"},
+}
+
+func TestScriptedAnswers(t *testing.T) {
+	core.DisableColor = true
+
+	for i, test := range tmplScriptedTests {
+		term := NewScriptedTerminal(test.answers...)
+
+		switch ask := test.ask.(type) {
+		case *KeyedInput:
+			ask.Terminal = term
+		case *SelectedInput:
+			ask.Terminal = term
+		}
+
+		ans, err := test.ask.Ask()
+
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		}
+
+		if ans != test.expected {
+			t.Errorf("%d: wrong answer, have (%s) want (%s)", i, ans, test.expected)
+		}
+
+		if term.Remaining() != 0 {
+			t.Errorf("%d: %d keystrokes were not read", i, term.Remaining())
+		}
+	}
+}
+
+func TestScriptedCapturesOutput(t *testing.T) {
+	core.DisableColor = true
+
+	term := NewScriptedTerminal("strong", "7")
+	ask := &KeyedInput{Prompt: Prompt{Question: "abv?", Validators: []Validator{IsANumber}, Terminal: term}}
+
+	if _, err := ask.Ask(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	out := term.Output()
+
+	for _, want := range []string{"abv?", "strong\n", "not a number", "7\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing (%s): %s", want, out)
+		}
+	}
+}
+
+func TestScriptedMasksPasswords(t *testing.T) {
+	core.DisableColor = true
+
+	term := NewScriptedTerminal("shh")
+	ask := &KeyedInput{Prompt: Prompt{Question: "password?", Terminal: term}, IsPassword: true}
+
+	ans, err := ask.Ask()
+
+	if err != nil || ans != "shh" {
+		t.Fatalf("wrong answer, have (%s, %v) want (shh, nil)", ans, err)
+	}
+
+	if !strings.Contains(term.Output(), "password? ***\n") {
+		t.Errorf("password was not masked while typing: %s", term.Output())
+	}
+}
+
+func TestScriptedEndOfScript(t *testing.T) {
+	ask := &KeyedInput{Prompt: Prompt{Question: "name?", Terminal: NewScriptedTerminal()}}
+
+	if _, err := ask.Ask(); err != io.EOF {
+		t.Errorf("wrong error, have (%v) want (%v)", err, io.EOF)
+	}
+}
+
+func TestScriptedEndOfScriptOnRequiredPrompt(t *testing.T) {
+	term := NewScriptedTerminal("")
+	ask := &KeyedInput{Prompt: Prompt{Question: "name?", Validators: []Validator{StringNotBlank}, Terminal: term}}
+
+	if _, err := ask.Ask(); err != io.EOF {
+		t.Errorf("wrong error, have (%v) want (%v)", err, io.EOF)
+	}
+}
+
+func TestScriptedInterrupt(t *testing.T) {
+	term := NewScriptedTerminal()
+	term.Type(string(terminal.KeyInterrupt))
+
+	ask := &KeyedInput{Prompt: Prompt{Question: "name?", Terminal: term}}
+
+	if _, err := ask.Ask(); err != ErrInterrupt {
+		t.Errorf("wrong error, have (%v) want (%v)", err, ErrInterrupt)
+	}
+}
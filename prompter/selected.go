@@ -3,22 +3,45 @@ package prompter
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"unicode"
 
 	"github.com/AlecAivazis/survey/core"
 	"github.com/AlecAivazis/survey/terminal"
 )
 
+// SelectedInput asks the user to pick one option, or several when IsMulti is set.
+// Typing filters the options with a fuzzy match on their labels and long lists are shown a page at
+// a time. Multi selects check every matching option with the right arrow and clear them with the left.
 type SelectedInput struct {
 	Prompt
-	IsMulti       bool
-	Options       []string
+	IsMulti bool
+	Options []string
+	// Values are returned in place of the displayed options when provided.
+	Values []string
+	// Descriptions are displayed next to the option that has focus.
+	Descriptions []string
+	MinPicks     int
+	MaxPicks     int
+	// PageSize is the number of options displayed at once (defaults to DefaultPageSize).
+	PageSize      int
 	selectedIndex int
 	checked       map[int]bool
 	showingHelp   bool
+	filter        []rune
+	term          Terminal
 }
 
+const (
+	DefaultPageSize = 7
+
+	// keyClearFilter (ctrl-u) clears the filter.
+	keyClearFilter = '\x15'
+
+	msgSelectPage    = "page %d of %d"
+	errNoOptionMatch = "no options match %q"
+)
+
 var SelectedInputTemplate = `
 {{- if .ShowHelp }}{{- color "cyan"}}{{ HelpIcon }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
 {{- color "green+hb"}}{{ QuestionIcon }} {{color "reset"}}
@@ -26,69 +49,194 @@ var SelectedInputTemplate = `
 {{- if .ShowAnswer}}{{color "cyan"}} {{.Answer}}{{color "reset"}}{{"\n"}}
 {{- else }}
   {{- if and .Help (not .ShowHelp)}} {{color "cyan"}}[{{ HelpInputRune }} for help]{{color "reset"}}{{end}}
+  {{- if .Filter}} {{color "cyan"}}[filter: {{.Filter}}]{{color "reset"}}{{end}}
   {{- "\n"}}
   {{- range $ix, $option := .Options}}
     {{- if eq $ix $.SelectedIndex}}{{color "cyan"}}{{ SelectFocusIcon }}{{color "reset"}}{{else}} {{end}}
     {{- if index $.Checked $ix}}{{color "green"}} {{ MarkedOptionIcon }} {{else}}{{color "default+hb"}} {{ UnmarkedOptionIcon }} {{end}}
     {{- color "reset"}}
-    {{- " "}}{{$option}}{{"\n"}}
+    {{- " "}}{{$option}}
+    {{- if eq $ix $.SelectedIndex}}{{with index $.Descriptions $ix}}{{color "cyan"}} - {{.}}{{color "reset"}}{{end}}{{end}}{{"\n"}}
   {{- end}}
+  {{- if .PageInfo}}{{color "cyan"}}  {{.PageInfo}}{{color "reset"}}{{"\n"}}{{end}}
 {{- end}}`
 
+// SelectedTemplateData holds the page of options being displayed. SelectedIndex and the keys of
+// Checked are positions on the page.
 type SelectedTemplateData struct {
 	InputTemplateData
 	Checked       map[int]bool
 	SelectedIndex int
 	Options       []string
+	Descriptions  []string
+	Filter        string
+	PageInfo      string
 }
 
 //MULTI
 // OnChange is called on every keypress.
 func (s *SelectedInput) OnChange(line []rune, pos int, key rune) (newLine []rune, newPos int, ok bool) {
-	if key == terminal.KeyArrowUp && s.selectedIndex > 0 {
-		// decrement the selected index
-		s.selectedIndex--
-		if !s.IsMulti {
-			s.checked = map[int]bool{s.selectedIndex: true}
-		}
-	} else if key == terminal.KeyArrowDown && s.selectedIndex < len(s.Options)-1 {
-		// if the user pressed down and there is room to move
-		// increment the selected index
-		s.selectedIndex++
-		if !s.IsMulti {
-			s.checked = map[int]bool{s.selectedIndex: true}
-		}
-	} else if key == terminal.KeySpace && s.IsMulti {
-		if old, ok := s.checked[s.selectedIndex]; !ok {
-			// otherwise just invert the current value
-			s.checked[s.selectedIndex] = true
-		} else {
-			// otherwise just invert the current value
-			s.checked[s.selectedIndex] = !old
-		}
+	switch {
+	case key == terminal.KeyArrowUp:
+		s.moveFocus(-1)
+	case key == terminal.KeyArrowDown:
+		s.moveFocus(1)
+	case key == terminal.KeySpace && s.IsMulti:
+		// invert the current value
+		s.checked[s.selectedIndex] = !s.checked[s.selectedIndex]
+	case key == keyArrowRight && s.IsMulti:
+		s.checkMatching(true)
+	case key == keyArrowLeft && s.IsMulti:
+		s.checkMatching(false)
+	case key == core.HelpInputRune && s.Help != "":
 		// only show the help message if we have one to show
-	} else if key == core.HelpInputRune && s.Help != "" {
 		s.showingHelp = true
+	case key == keyBackspace || key == keyDelete:
+		if len(s.filter) > 0 {
+			s.filter = s.filter[:len(s.filter)-1]
+			s.focusMatching()
+		}
+	case key == keyClearFilter:
+		s.filter = nil
+		s.focusMatching()
+	case unicode.IsPrint(key):
+		s.filter = append(s.filter, key)
+		s.focusMatching()
 	}
 
-	// render the options
-	s.Render(
-		SelectedInputTemplate,
-		SelectedTemplateData{
-			InputTemplateData: InputTemplateData{
-				Prompt:   s.Prompt,
-				ShowHelp: s.showingHelp,
-			},
-			SelectedIndex: s.selectedIndex,
-			Checked:       s.checked,
-			Options:       s.Options,
-		},
-	)
+	s.render()
 
 	// if we are not pressing ent
 	return line, 0, true
 }
 
+// render displays the page of matching options that holds the focused option.
+func (s *SelectedInput) render() {
+	if s.term == nil {
+		s.term = s.terminal()
+	}
+
+	pageSize := s.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	matching := s.matching()
+	focus := indexOf(matching, s.selectedIndex)
+	if focus < 0 {
+		focus = 0
+	}
+
+	first := (focus / pageSize) * pageSize
+	last := first + pageSize
+	if last > len(matching) {
+		last = len(matching)
+	}
+
+	descriptions := s.descriptions()
+	data := SelectedTemplateData{
+		InputTemplateData: InputTemplateData{
+			Prompt:   s.Prompt,
+			ShowHelp: s.showingHelp,
+		},
+		SelectedIndex: focus - first,
+		Checked:       make(map[int]bool),
+		Options:       []string{},
+		Descriptions:  []string{},
+		Filter:        string(s.filter),
+	}
+
+	for ix, optIndex := range matching[first:last] {
+		data.Options = append(data.Options, s.Options[optIndex])
+		data.Descriptions = append(data.Descriptions, descriptions[optIndex])
+		data.Checked[ix] = s.checked[optIndex]
+	}
+
+	if len(matching) > pageSize {
+		data.PageInfo = fmt.Sprintf(msgSelectPage, first/pageSize+1, (len(matching)+pageSize-1)/pageSize)
+	}
+
+	// render the options
+	s.term.Render(SelectedInputTemplate, data)
+}
+
+// moveFocus moves the focus up or down the matching options.
+func (s *SelectedInput) moveFocus(delta int) {
+	matching := s.matching()
+	focus := indexOf(matching, s.selectedIndex) + delta
+
+	if focus < 0 || focus >= len(matching) {
+		return
+	}
+
+	s.focus(matching[focus])
+}
+
+// focusMatching moves the focus to the first matching option when the focused one no longer matches.
+func (s *SelectedInput) focusMatching() {
+	matching := s.matching()
+
+	if len(matching) > 0 && indexOf(matching, s.selectedIndex) < 0 {
+		s.focus(matching[0])
+	}
+}
+
+func (s *SelectedInput) focus(optIndex int) {
+	s.selectedIndex = optIndex
+
+	if !s.IsMulti {
+		s.checked = map[int]bool{s.selectedIndex: true}
+	}
+}
+
+// checkMatching checks or unchecks every option that matches the filter.
+func (s *SelectedInput) checkMatching(checked bool) {
+	for _, optIndex := range s.matching() {
+		s.checked[optIndex] = checked
+	}
+}
+
+// matching returns the index of every option that matches the filter.
+func (s *SelectedInput) matching() []int {
+	pattern := strings.ToLower(string(s.filter))
+	matches := []int{}
+
+	for i, opt := range s.Options {
+		if fuzzyMatch(pattern, strings.ToLower(opt)) {
+			matches = append(matches, i)
+		}
+	}
+
+	return matches
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in target in order.
+func fuzzyMatch(pattern, target string) bool {
+	remaining := []rune(pattern)
+
+	for _, r := range target {
+		if len(remaining) < 1 {
+			break
+		}
+
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+
+	return len(remaining) < 1
+}
+
+func indexOf(haystack []int, needle int) int {
+	for i, v := range haystack {
+		if v == needle {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func (s *SelectedInput) Ask() (string, error) {
 	// if there are no options to render
 	if len(s.Options) == 0 {
@@ -98,12 +246,14 @@ func (s *SelectedInput) Ask() (string, error) {
 
 	// compute the default state
 	s.checked = make(map[int]bool)
+	s.filter = nil
+	s.showingHelp = false
 	defaults := strings.Split(s.Default, ",")
 
 	// if there is a default
 	if len(defaults) > 0 {
 		for _, dflt := range defaults {
-			for i, opt := range s.Options {
+			for i, opt := range s.values() {
 				// if the option correponds to the default
 				if opt == dflt {
 					// we found our initial value
@@ -118,35 +268,44 @@ func (s *SelectedInput) Ask() (string, error) {
 		}
 	}
 
-	// hide the cursor
-	terminal.CursorHide()
-	// show the cursor when we're done
-	defer terminal.CursorShow()
-
 	if s.BeforePrompt != nil {
 		s.BeforePrompt()
 	}
 
-	s.Render(
-		SelectedInputTemplate,
-		SelectedTemplateData{
-			InputTemplateData: InputTemplateData{
-				Prompt: s.Prompt,
-			},
-			SelectedIndex: s.selectedIndex,
-			Checked:       s.checked,
-			Options:       s.Options,
-		},
-	)
+	s.term = s.terminal()
+
+	if ch, ok := s.term.(cursorHider); ok {
+		// hide the cursor
+		ch.HideCursor()
+		// show the cursor when we're done
+		defer ch.ShowCursor()
+	}
 
-	rr := terminal.NewRuneReader(os.Stdin)
-	rr.SetTermMode()
-	defer rr.RestoreTermMode()
+	s.render()
+
+	s.term.Start()
+	defer s.term.Stop()
 
 	// start waiting for input
 	for {
-		r, _, _ := rr.ReadRune()
+		r, err := s.term.ReadKey()
+		if err != nil {
+			return "", err
+		}
 		if r == '\r' || r == '\n' {
+			// a single selection has to be one of the matching options
+			if !s.IsMulti && len(s.matching()) < 1 {
+				s.term.Error(fmt.Errorf(errNoOptionMatch, string(s.filter)))
+				s.OnChange(nil, 0, 0)
+				continue
+			}
+
+			// don't accept the selection until the number of picks is acceptable
+			if perr := s.checkPicks(); perr != nil {
+				s.term.Error(perr)
+				s.OnChange(nil, 0, 0)
+				continue
+			}
 			break
 		}
 		if r == terminal.KeyInterrupt {
@@ -159,7 +318,7 @@ func (s *SelectedInput) Ask() (string, error) {
 	}
 
 	answers := []string{}
-	for ix, option := range s.Options {
+	for ix, option := range s.values() {
 		if val, ok := s.checked[ix]; ok && val {
 			answers = append(answers, option)
 		}
@@ -167,3 +326,43 @@ func (s *SelectedInput) Ask() (string, error) {
 
 	return strings.Join(answers, ","), nil
 }
+
+func (s *SelectedInput) checkPicks() error {
+	if !s.IsMulti {
+		return nil
+	}
+
+	picked := 0
+	for _, checked := range s.checked {
+		if checked {
+			picked++
+		}
+	}
+
+	if s.MinPicks > 0 && picked < s.MinPicks {
+		return fmt.Errorf("please pick at least %d options", s.MinPicks)
+	}
+
+	if s.MaxPicks > 0 && picked > s.MaxPicks {
+		return fmt.Errorf("please pick at most %d options", s.MaxPicks)
+	}
+
+	return nil
+}
+
+// values returns what gets answered for each option
+func (s *SelectedInput) values() []string {
+	if len(s.Values) == len(s.Options) {
+		return s.Values
+	}
+
+	return s.Options
+}
+
+// descriptions returns a description for every option so the template can index it safely
+func (s *SelectedInput) descriptions() []string {
+	descs := make([]string, len(s.Options))
+	copy(descs, s.Descriptions)
+
+	return descs
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/AlecAivazis/survey/core"
@@ -155,6 +156,33 @@ var tmplSelectedTests = []struct {
 		[]string{"?\x0e\x0e\x10\x0e"},
 		"three",
 	},
+	{
+		&SelectedInput{
+			Prompt: Prompt{
+				Question: "11",
+				Default:  "2",
+			},
+			Options:      []string{"one", "two", "three", "four"},
+			Values:       []string{"1", "2", "3", "4"},
+			Descriptions: []string{"the first", "the second"},
+		},
+		[]string{"\x0e"},
+		"3",
+	},
+	{
+		&SelectedInput{
+			Prompt: Prompt{
+				Question: "12",
+			},
+			Options:  []string{"one", "two", "three", "four"},
+			Values:   []string{"1", "2", "3", "4"},
+			IsMulti:  true,
+			MinPicks: 1,
+			MaxPicks: 2,
+		},
+		[]string{" \x0e\x0e "},
+		"1,3",
+	},
 }
 
 func TestSelectedInput(t *testing.T) {
@@ -232,3 +260,123 @@ func TestSelectedErrorInput(t *testing.T) {
 		}
 	}
 }
+
+var checkPicksTests = []struct {
+	si       *SelectedInput
+	checked  map[int]bool
+	expected string
+}{
+	{
+		&SelectedInput{IsMulti: true, MinPicks: 2},
+		map[int]bool{0: true, 1: false},
+		"please pick at least 2 options",
+	},
+	{
+		&SelectedInput{IsMulti: true, MaxPicks: 1},
+		map[int]bool{0: true, 1: true},
+		"please pick at most 1 options",
+	},
+	{
+		&SelectedInput{IsMulti: true, MinPicks: 1, MaxPicks: 2},
+		map[int]bool{0: true, 1: true},
+		"nil",
+	},
+	{
+		&SelectedInput{MinPicks: 2},
+		map[int]bool{0: true},
+		"nil",
+	},
+}
+
+func TestCheckPicks(t *testing.T) {
+	for _, cpt := range checkPicksTests {
+		errString := "nil"
+		cpt.si.checked = cpt.checked
+
+		if err := cpt.si.checkPicks(); err != nil {
+			errString = err.Error()
+		}
+
+		if errString != cpt.expected {
+			t.Errorf("CPT fail have (%s) want (%s)", errString, cpt.expected)
+		}
+	}
+}
+
+var styles = []string{"ipa", "imperial stout", "stout", "lager", "pilsner", "porter", "saison", "dubbel", "tripel", "bock"}
+
+var filterTests = []struct {
+	multi    bool
+	answers  []string
+	expected string
+}{
+	{false, []string{"sto"}, "imperial stout"},
+	{false, []string{"STO\x0e"}, "stout"},
+	{false, []string{"zz\x7f\x7fla"}, "lager"},
+	{false, []string{"zz", "\x15\x0e"}, "imperial stout"},
+	{false, []string{"\x0e\x0ep"}, "ipa"},
+	{true, []string{"\x06"}, strings.Join(styles, ",")},
+	{true, []string{"st\x06"}, "imperial stout,stout"},
+	{true, []string{"\x06st\x02"}, "ipa,lager,pilsner,porter,saison,dubbel,tripel,bock"},
+	{true, []string{"bo \x15\x10 "}, "tripel,bock"},
+}
+
+func TestSelectedFilter(t *testing.T) {
+	core.DisableColor = true
+
+	for i, test := range filterTests {
+		term := NewScriptedTerminal(test.answers...)
+		ask := &SelectedInput{Prompt: Prompt{Question: "style?", Terminal: term}, Options: styles, IsMulti: test.multi}
+
+		ans, err := ask.Ask()
+
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		}
+
+		if ans != test.expected {
+			t.Errorf("%d: wrong answer, have (%s) want (%s)", i, ans, test.expected)
+		}
+	}
+}
+
+func TestSelectedNoMatchError(t *testing.T) {
+	core.DisableColor = true
+
+	term := NewScriptedTerminal("zz", "\x7f\x7f")
+	ask := &SelectedInput{Prompt: Prompt{Question: "style?", Terminal: term}, Options: styles}
+
+	if _, err := ask.Ask(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(term.Output(), `no options match "zz"`) {
+		t.Errorf("output is missing the error: %s", term.Output())
+	}
+}
+
+func TestSelectedPaging(t *testing.T) {
+	core.DisableColor = true
+
+	term := NewScriptedTerminal("\x0e\x0e\x0e\x0e")
+	ask := &SelectedInput{Prompt: Prompt{Question: "style?", Terminal: term}, Options: styles, PageSize: 3}
+
+	ans, err := ask.Ask()
+
+	if err != nil || ans != "pilsner" {
+		t.Fatalf("wrong answer, have (%s, %v) want (pilsner, nil)", ans, err)
+	}
+
+	out := term.Output()
+	last := out[strings.LastIndex(out, "style?"):]
+
+	for _, want := range []string{"page 2 of 4", "lager", "pilsner", "porter"} {
+		if !strings.Contains(last, want) {
+			t.Errorf("page is missing (%s): %s", want, last)
+		}
+	}
+
+	if strings.Contains(last, "ipa") || strings.Contains(last, "saison") {
+		t.Errorf("page shows options from other pages: %s", last)
+	}
+}
@@ -0,0 +1,111 @@
+package prompter
+
+import (
+	"errors"
+	"os"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/AlecAivazis/survey/terminal"
+)
+
+// keys the prompts read that aren't defined by the survey terminal package skelp is pinned to.
+// The values are the ones survey uses.
+const (
+	keyArrowLeft  = '\x02'
+	keyArrowRight = '\x06'
+	keyBackspace  = '\b'
+	keyDelete     = '\x7f'
+)
+
+// ErrInterrupt is returned by a ScriptedTerminal when the user interrupts a prompt. It has the
+// message of the error returned by the survey terminal.
+var ErrInterrupt = errors.New("interrupt")
+
+// Terminal is what a Prompter reads keystrokes from and renders its question to.
+// Prompts use the process' stdin and stdout unless their Terminal is set.
+type Terminal interface {
+	// Start prepares the terminal for reading keystrokes and Stop puts it back.
+	Start() error
+	Stop() error
+
+	// Render writes the prompt rendered from the template, replacing what was rendered before.
+	Render(tmpl string, data interface{}) error
+
+	// Error tells the user why an answer was rejected.
+	Error(invalid error) error
+
+	// ReadLine reads keystrokes up to enter. When mask isn't 0 it's echoed in place of each keystroke.
+	ReadLine(mask rune) ([]rune, error)
+
+	// ReadKey reads a single keystroke without waiting for enter.
+	ReadKey() (rune, error)
+}
+
+// cursorHider is implemented by terminals with a cursor that should be hidden while a choice is made.
+type cursorHider interface {
+	HideCursor()
+	ShowCursor()
+}
+
+// stdioTerminal drives prompts through survey on the real terminal.
+type stdioTerminal struct {
+	renderer *core.Renderer
+	rr       *terminal.RuneReader
+}
+
+func newStdioTerminal(renderer *core.Renderer) *stdioTerminal {
+	return &stdioTerminal{
+		renderer: renderer,
+		rr:       terminal.NewRuneReader(os.Stdin),
+	}
+}
+
+func (st *stdioTerminal) Start() error {
+	return st.rr.SetTermMode()
+}
+
+func (st *stdioTerminal) Stop() error {
+	return st.rr.RestoreTermMode()
+}
+
+func (st *stdioTerminal) Render(tmpl string, data interface{}) error {
+	return st.renderer.Render(tmpl, data)
+}
+
+func (st *stdioTerminal) Error(invalid error) error {
+	return st.renderer.Error(invalid)
+}
+
+func (st *stdioTerminal) ReadLine(mask rune) ([]rune, error) {
+	line, err := st.rr.ReadLine(mask)
+
+	if err == nil {
+		// terminal will echo the \n so we need to jump back up one row
+		terminal.CursorPreviousLine(1)
+	}
+
+	return line, err
+}
+
+func (st *stdioTerminal) ReadKey() (rune, error) {
+	r, _, err := st.rr.ReadRune()
+
+	return r, err
+}
+
+func (st *stdioTerminal) HideCursor() {
+	terminal.CursorHide()
+}
+
+func (st *stdioTerminal) ShowCursor() {
+	terminal.CursorShow()
+}
+
+// terminal returns the terminal the prompt should use.
+func (p *Prompt) terminal() Terminal {
+	if p.Terminal != nil {
+		return p.Terminal
+	}
+
+	return newStdioTerminal(&p.Renderer)
+}
@@ -2,9 +2,12 @@ package prompter
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -38,6 +41,16 @@ func IsANumber(val string) error {
 	return nil
 }
 
+func IsAnInteger(val string) error {
+	_, err := strconv.Atoi(strings.TrimSpace(val))
+
+	if err != nil {
+		return fmt.Errorf("%q is not a whole number, please try again.", val)
+	}
+
+	return nil
+}
+
 func GreaterThanZero(val string) error {
 	f, err := strconv.ParseFloat(val, 64)
 
@@ -121,3 +134,52 @@ func (mm *MinMaxNumber) CheckMax(val string) error {
 
 	return nil
 }
+
+type DateLayout struct {
+	Layout string
+}
+
+func (dl *DateLayout) CheckDate(val string) error {
+	if len(strings.TrimSpace(val)) < 1 {
+		return nil
+	}
+
+	if _, err := time.Parse(dl.Layout, strings.TrimSpace(val)); err != nil {
+		return fmt.Errorf("%q is not a date in the format %s, please try again.", val, dl.Layout)
+	}
+
+	return nil
+}
+
+type PathCheck struct {
+	MustExist bool
+	IsDir     bool
+	BaseDir   string
+}
+
+func (pc *PathCheck) CheckPath(val string) error {
+	if len(strings.TrimSpace(val)) < 1 {
+		return nil
+	}
+
+	path := val
+	if !filepath.IsAbs(path) && len(pc.BaseDir) > 0 {
+		path = filepath.Join(pc.BaseDir, path)
+	}
+
+	fi, err := os.Stat(path)
+
+	if err != nil {
+		if pc.MustExist {
+			return fmt.Errorf("%q does not exist, please try again.", val)
+		}
+
+		return nil
+	}
+
+	if pc.IsDir && !fi.IsDir() {
+		return fmt.Errorf("%q is not a directory, please try again.", val)
+	}
+
+	return nil
+}
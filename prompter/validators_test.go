@@ -2,6 +2,9 @@ package prompter
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -460,3 +463,151 @@ func TestMaxNumber(t *testing.T) {
 		}
 	}
 }
+
+var integerTests = []struct {
+	in       string
+	expected error
+}{
+	{
+		"1",
+		nil,
+	},
+	{
+		" 8080 ",
+		nil,
+	},
+	{
+		"-3",
+		nil,
+	},
+	{
+		"2.5",
+		fmt.Errorf("%q is not a whole number, please try again.", "2.5"),
+	},
+	{
+		"two",
+		fmt.Errorf("%q is not a whole number, please try again.", "two"),
+	},
+}
+
+func TestIsAnInteger(t *testing.T) {
+	for _, iai := range integerTests {
+		errString, expString := "nil", "nil"
+		err := IsAnInteger(iai.in)
+
+		if err != nil {
+			errString = err.Error()
+		}
+
+		if iai.expected != nil {
+			expString = iai.expected.Error()
+		}
+
+		if errString != expString {
+			t.Errorf("IAI fail have (%s) want (%s)", errString, expString)
+		}
+	}
+}
+
+var dateTests = []struct {
+	in       string
+	layout   string
+	expected error
+}{
+	{
+		"2017-06-07",
+		"2006-01-02",
+		nil,
+	},
+	{
+		"",
+		"2006-01-02",
+		nil,
+	},
+	{
+		"06/07/2017",
+		"01/02/2006",
+		nil,
+	},
+	{
+		"06/07/2017",
+		"2006-01-02",
+		fmt.Errorf("%q is not a date in the format %s, please try again.", "06/07/2017", "2006-01-02"),
+	},
+}
+
+func TestCheckDate(t *testing.T) {
+	for _, dt := range dateTests {
+		errString, expString := "nil", "nil"
+		validator := &DateLayout{Layout: dt.layout}
+		err := validator.CheckDate(dt.in)
+
+		if err != nil {
+			errString = err.Error()
+		}
+
+		if dt.expected != nil {
+			expString = dt.expected.Error()
+		}
+
+		if errString != expString {
+			t.Errorf("DT fail have (%s) want (%s)", errString, expString)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-path-check")
+	defer os.RemoveAll(tmpDir)
+
+	ioutil.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("hi"), 0644)
+
+	var pathTests = []struct {
+		in       string
+		check    *PathCheck
+		expected error
+	}{
+		{
+			tmpDir,
+			&PathCheck{MustExist: true, IsDir: true},
+			nil,
+		},
+		{
+			"file.txt",
+			&PathCheck{MustExist: true, BaseDir: tmpDir},
+			nil,
+		},
+		{
+			"nope",
+			&PathCheck{BaseDir: tmpDir},
+			nil,
+		},
+		{
+			"nope",
+			&PathCheck{MustExist: true, BaseDir: tmpDir},
+			fmt.Errorf("%q does not exist, please try again.", "nope"),
+		},
+		{
+			"file.txt",
+			&PathCheck{IsDir: true, BaseDir: tmpDir},
+			fmt.Errorf("%q is not a directory, please try again.", "file.txt"),
+		},
+	}
+
+	for _, pt := range pathTests {
+		errString, expString := "nil", "nil"
+		err := pt.check.CheckPath(pt.in)
+
+		if err != nil {
+			errString = err.Error()
+		}
+
+		if pt.expected != nil {
+			expString = pt.expected.Error()
+		}
+
+		if errString != expString {
+			t.Errorf("PT fail have (%s) want (%s)", errString, expString)
+		}
+	}
+}
@@ -10,6 +10,32 @@ type OverwriteProvider func(rootDir, relFile string) bool
 
 type BasicAuthProvider func() (string, string)
 
+// Include is another template to generate into a sub directory of the output directory using its own DataProvider
+type Include struct {
+	TemplateID   string
+	Dir          string
+	DataProvider DataProvider
+}
+
+// IncludesProvider is a function that returns the templates included by the template at templateRoot.
+// It is called after the DataProvider has gathered the data for that template.
+type IncludesProvider func(templateRoot string) ([]Include, error)
+
+// TemplateResolver is a function that returns the local path of a template, downloading it if needed
+type TemplateResolver func(templateID string) (string, error)
+
+// ParentProvider is a function that returns the local path of the template extended by the template at templateRoot.
+// A blank path means the template doesn't extend another template.
+type ParentProvider func(templateRoot string, resolver TemplateResolver) (string, error)
+
+// StrictProvider is a function that returns whether the template at templateRoot fails on keys missing from the data.
+// It is called after the DataProvider has gathered the data for that template.
+type StrictProvider func(templateRoot string) (bool, error)
+
+// FuncsReceiver is a function that's given the template functions of the generator so a data provider
+// can use them in its own templates. It is called before the DataProvider.
+type FuncsReceiver func(funcs map[string]interface{}) error
+
 func DefaultOverwriteProvider(rootDir, relFile string) bool {
 	return false
 }
@@ -20,6 +46,8 @@ func AlwaysOverwriteProvider(rootDir, relFile string) bool {
 
 type DefaultBasicAuthProvider struct {
 	BeforePrompt func()
+	// Terminal is asked for the credentials instead of stdin and stdout when set.
+	Terminal prompter.Terminal
 }
 
 func (bap *DefaultBasicAuthProvider) ProvideAuth() (string, string) {
@@ -28,6 +56,7 @@ func (bap *DefaultBasicAuthProvider) ProvideAuth() (string, string) {
 	userPrompt := &prompter.KeyedInput{
 		Prompt: prompter.Prompt{
 			BeforePrompt: bap.BeforePrompt,
+			Terminal:     bap.Terminal,
 			Question:     "enter username:",
 		},
 	}
@@ -35,6 +64,7 @@ func (bap *DefaultBasicAuthProvider) ProvideAuth() (string, string) {
 	passPrompt := &prompter.KeyedInput{
 		Prompt: prompter.Prompt{
 			BeforePrompt: bap.BeforePrompt,
+			Terminal:     bap.Terminal,
 			Question:     "enter password:",
 		},
 		IsPassword: true,
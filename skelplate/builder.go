@@ -0,0 +1,368 @@
+package skelplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/brainicorn/skelp/prompter"
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const (
+	ErrSkelplateExists = "skelp.json already exists: %s"
+
+	skelpTemplatesDirname = "templates"
+	skelpReadmeFilename   = "README.md"
+
+	promptNewAuthor      = "Who is the author of the template:"
+	promptNewDescription = "Enter a description of the template:"
+	promptNewRepository  = "Enter the repository url of the template (can be blank):"
+	promptNewAddVariable = "Would you like to add a variable:"
+	promptNewVarName     = "Enter a name for the variable:"
+	promptNewVarKind     = "What kind of variable is %s:"
+	promptNewVarDefault  = "Enter a default value for %s:"
+	promptNewVarDefaults = "Enter the default values for %s (comma separated):"
+	promptNewVarPrompt   = "Enter the question to ask for %s (blank for the standard question):"
+	promptNewVarRequired = "Is a value required for %s:"
+	promptNewVarChoices  = "Enter the choices for %s (comma separated):"
+	promptNewVarMulti    = "Can more than one choice be picked for %s:"
+	promptNewVarValue    = "Enter the template that computes %s:"
+	promptNewObjAddVar   = "Would you like to add another variable to %s:"
+	promptNewObjRepeated = "Can more than one %s be entered:"
+	promptNewObjKey      = "Enter the name of the variable that keys each %s (blank for a list):"
+
+	errDuplicateVarName = "a variable named %s already exists, please try again."
+	errInvalidVarName   = "%s is not a valid variable name, please try again."
+	errNotAChoiceAgain  = "%s is not one of the choices, please try again."
+	errNotAVarAgain     = "%s is not a variable of %s, please try again."
+)
+
+var (
+	varNameRegExp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	varKindLabels = []string{"simple value", "value with a prompt and rules", "selection", "multiple values", "computed value", "object with nested variables"}
+	varKindValues = []string{typeSimple, typeComplex, typeSelect, typeMultiVal, typeComputed, typeObject}
+)
+
+// SkelplateBuilder asks the user questions to build the descriptor for a new skelplate.
+type SkelplateBuilder struct {
+	beforePrompt func()
+	terminal     prompter.Terminal
+}
+
+func NewSkelplateBuilder() *SkelplateBuilder {
+	return &SkelplateBuilder{}
+}
+
+// SetTerminal makes the builder ask its questions on term instead of stdin and stdout.
+func (sb *SkelplateBuilder) SetTerminal(term prompter.Terminal) {
+	sb.terminal = term
+}
+
+// BuildDescriptor asks for the template information and then for variables until the user is done.
+func (sb *SkelplateBuilder) BuildDescriptor() (SkelplateDescriptor, error) {
+	var err error
+	var descriptor SkelplateDescriptor
+	var more bool
+	var tvar TemplateVariable
+
+	now := time.Now().UTC().Truncate(time.Second)
+	descriptor.TemplateCreated = now
+	descriptor.TemplateModified = now
+	descriptor.TemplateVariables = []TemplateVariable{}
+
+	descriptor.TemplateAuthor, err = sb.ask(promptNewAuthor, "", prompter.StringNotBlank)
+
+	if err == nil {
+		descriptor.TemplateDesc, err = sb.ask(promptNewDescription, "")
+	}
+
+	if err == nil {
+		descriptor.TemplateRepo, err = sb.ask(promptNewRepository, "")
+	}
+
+	if err == nil {
+		more, err = sb.confirm(promptNewAddVariable, "y")
+	}
+
+	for err == nil && more {
+		tvar, err = sb.buildVariable(descriptor.TemplateVariables)
+
+		if err == nil {
+			descriptor.TemplateVariables = append(descriptor.TemplateVariables, tvar)
+			more, err = sb.confirm(promptNewAddVariable, "n")
+		}
+	}
+
+	return descriptor, err
+}
+
+func (sb *SkelplateBuilder) buildVariable(existing []TemplateVariable) (TemplateVariable, error) {
+	var err error
+	var name, kind, defval string
+	var tvar TemplateVariable
+
+	uniqueName := func(val string) error {
+		if !varNameRegExp.MatchString(strings.TrimSpace(val)) {
+			return fmt.Errorf(errInvalidVarName, val)
+		}
+
+		for _, v := range existing {
+			if v.Name() == strings.TrimSpace(val) {
+				return fmt.Errorf(errDuplicateVarName, val)
+			}
+		}
+
+		return nil
+	}
+
+	name, err = sb.ask(promptNewVarName, "", prompter.StringNotBlank, uniqueName)
+	name = strings.TrimSpace(name)
+
+	if err == nil {
+		kindInput := &prompter.SelectedInput{
+			Prompt: prompter.Prompt{
+				Question:     fmt.Sprintf(promptNewVarKind, name),
+				Default:      typeSimple,
+				BeforePrompt: sb.beforePrompt,
+				Terminal:     sb.terminal,
+			},
+			Options: varKindLabels,
+			Values:  varKindValues,
+		}
+
+		kind, err = kindInput.Ask()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case typeComplex:
+		cv := &ComplexVar{SimpleVar: SimpleVar{Varname: name}}
+		defval, err = sb.ask(fmt.Sprintf(promptNewVarDefault, name), "")
+		cv.DefaultVal = defval
+
+		if err == nil {
+			cv.Prompt, err = sb.ask(fmt.Sprintf(promptNewVarPrompt, name), "")
+		}
+
+		if err == nil {
+			cv.Required, err = sb.confirm(fmt.Sprintf(promptNewVarRequired, name), "n")
+		}
+
+		tvar = cv
+	case typeSelect:
+		tvar, err = sb.buildSelection(name)
+	case typeMultiVal:
+		mv := &MultiValue{ComplexVar: ComplexVar{SimpleVar: SimpleVar{Varname: name}}, IsMultiVal: true}
+		defval, err = sb.ask(fmt.Sprintf(promptNewVarDefaults, name), "")
+		mv.DefaultVal = splitList(defval)
+		tvar = mv
+	case typeComputed:
+		c := &Computed{Varname: name, IsComputed: true}
+		c.Value, err = sb.ask(fmt.Sprintf(promptNewVarValue, name), "", prompter.StringNotBlank)
+		tvar = c
+	case typeObject:
+		tvar, err = sb.buildObject(name)
+	default:
+		sv := &SimpleVar{Varname: name}
+		defval, err = sb.ask(fmt.Sprintf(promptNewVarDefault, name), "")
+		sv.DefaultVal = defval
+		tvar = sv
+	}
+
+	return tvar, err
+}
+
+// buildObject asks for the nested variables of an object, at least one, and whether it's repeated.
+func (sb *SkelplateBuilder) buildObject(name string) (*ObjectVar, error) {
+	var err error
+	var nested TemplateVariable
+	var key string
+
+	ov := &ObjectVar{Varname: name, Variables: []TemplateVariable{}}
+
+	for more := true; err == nil && more; {
+		nested, err = sb.buildVariable(ov.Variables)
+
+		if err == nil {
+			ov.Variables = append(ov.Variables, nested)
+			more, err = sb.confirm(fmt.Sprintf(promptNewObjAddVar, name), "n")
+		}
+	}
+
+	if err == nil {
+		ov.Repeated, err = sb.confirm(fmt.Sprintf(promptNewObjRepeated, name), "n")
+	}
+
+	isANestedVar := func(val string) error {
+		if skelputil.IsBlank(val) {
+			return nil
+		}
+
+		for _, v := range ov.Variables {
+			if v.Name() == strings.TrimSpace(val) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf(errNotAVarAgain, val, name)
+	}
+
+	if err == nil && ov.Repeated {
+		key, err = sb.ask(fmt.Sprintf(promptNewObjKey, name), "", isANestedVar)
+		ov.Key = strings.TrimSpace(key)
+	}
+
+	return ov, err
+}
+
+func (sb *SkelplateBuilder) buildSelection(name string) (*Selection, error) {
+	var err error
+	var choices, defval string
+
+	sel := &Selection{ComplexVar: ComplexVar{SimpleVar: SimpleVar{Varname: name}}}
+
+	choices, err = sb.ask(fmt.Sprintf(promptNewVarChoices, name), "", prompter.StringNotBlank)
+
+	for _, c := range splitList(choices) {
+		sel.Choices.Options = append(sel.Choices.Options, Choice{Value: c.(string)})
+	}
+
+	if err == nil {
+		sel.MultipleChoice, err = sb.confirm(fmt.Sprintf(promptNewVarMulti, name), "n")
+	}
+
+	isAChoice := func(val string) error {
+		for _, d := range splitList(val) {
+			if !containsString(sel.Choices.Values(), d.(string)) {
+				return fmt.Errorf(errNotAChoiceAgain, d)
+			}
+		}
+
+		return nil
+	}
+
+	if err == nil {
+		defval, err = sb.ask(fmt.Sprintf(promptNewVarDefault, name), "", isAChoice)
+	}
+
+	sel.DefaultVal = defval
+	if sel.MultipleChoice {
+		sel.DefaultVal = splitList(defval)
+	}
+
+	return sel, err
+}
+
+func (sb *SkelplateBuilder) ask(question, defval string, validators ...prompter.Validator) (string, error) {
+	input := &prompter.KeyedInput{
+		Prompt: prompter.Prompt{
+			Question:     question,
+			Default:      defval,
+			Validators:   validators,
+			BeforePrompt: sb.beforePrompt,
+			Terminal:     sb.terminal,
+		},
+	}
+
+	return input.Ask()
+}
+
+func (sb *SkelplateBuilder) confirm(question, defval string) (bool, error) {
+	input := &prompter.KeyedInput{
+		Prompt: prompter.Prompt{
+			Question:     question,
+			Default:      defval,
+			BeforePrompt: sb.beforePrompt,
+			Terminal:     sb.terminal,
+		},
+		IsConfirm: true,
+	}
+
+	return prompter.AsBool(input.Ask())
+}
+
+// CreateSkelplate writes the descriptor and a templates skeleton to dir.
+// The descriptor is checked against the schema before anything is written and a README already
+// in the templates folder is kept.
+func CreateSkelplate(dir string, descriptor SkelplateDescriptor) error {
+	err := writeDescriptor(dir, descriptor)
+	readmePath := filepath.Join(dir, skelpTemplatesDirname, skelpReadmeFilename)
+
+	if err == nil && !skelputil.PathExists(readmePath) {
+		err = ioutil.WriteFile(readmePath, skeletonReadme(descriptor), 0644)
+	}
+
+	return err
+}
+
+// writeDescriptor validates the descriptor and writes it to dir along with an empty templates folder.
+func writeDescriptor(dir string, descriptor SkelplateDescriptor) error {
+	var err error
+	var descriptorBytes []byte
+	var schemaViolations []string
+
+	jsonPath := filepath.Join(dir, skelpFilename)
+	if skelputil.PathExists(jsonPath) {
+		return fmt.Errorf(ErrSkelplateExists, jsonPath)
+	}
+
+	descriptorBytes, err = json.MarshalIndent(descriptor, "", "  ")
+
+	if err == nil {
+		schemaViolations, err = validateDescriptorSchema(descriptorBytes)
+	}
+
+	if err == nil && len(schemaViolations) > 0 {
+		err = newSchemaError(schemaViolations)
+	}
+
+	if err == nil {
+		err = os.MkdirAll(filepath.Join(dir, skelpTemplatesDirname), os.ModePerm)
+	}
+
+	if err == nil {
+		err = ioutil.WriteFile(jsonPath, descriptorBytes, 0644)
+	}
+
+	return err
+}
+
+// skeletonReadme creates a starting template that uses every variable.
+func skeletonReadme(descriptor SkelplateDescriptor) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("## {{.TemplateDesc}}\n\nby {{.TemplateAuthor}}\n")
+
+	if len(descriptor.TemplateVariables) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, v := range descriptor.TemplateVariables {
+		b.WriteString(fmt.Sprintf("- %s: {{.%s}}\n", v.Name(), v.Name()))
+	}
+
+	return b.Bytes()
+}
+
+// splitList splits a comma separated answer into its trimmed, non-blank entries.
+func splitList(answer string) []interface{} {
+	entries := []interface{}{}
+
+	for _, e := range strings.Split(answer, ",") {
+		if !skelputil.IsBlank(e) {
+			entries = append(entries, strings.TrimSpace(e))
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,169 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/brainicorn/skelp/prompter"
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+func TestBuildAndCreateSkelplate(t *testing.T) {
+	core.DisableColor = true
+
+	user := newFakeInterruptingUser([]string{
+		"brainicorn", "a beer template", "", "y",
+		"beer", "", "ipa", "y",
+		"style", "\x0e\x0e", "ipa, stout", "n", "lager", "stout", "y",
+		"abv", "\x0e", "5", "", "y", "y",
+		"tags", "\x0e\x0e\x0e", "hoppy, dark", "y",
+		"loud", "\x0e\x0e\x0e\x0e", "{{.beer | upper}}", "n",
+	})
+	defer user.done()
+
+	builder := NewSkelplateBuilder()
+	builder.beforePrompt = user.nextKeystroke
+
+	descriptor, err := builder.BuildDescriptor()
+
+	if err != nil {
+		t.Fatalf("error building descriptor: %s", err)
+	}
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-new-test")
+	defer os.RemoveAll(tmpDir)
+
+	err = CreateSkelplate(tmpDir, descriptor)
+
+	if err != nil {
+		t.Fatalf("error creating skelplate: %s", err)
+	}
+
+	descriptorBytes, _ := ioutil.ReadFile(filepath.Join(tmpDir, skelpFilename))
+
+	var created SkelplateDescriptor
+	err = json.Unmarshal(descriptorBytes, &created)
+
+	if err != nil {
+		t.Fatalf("error parsing created descriptor: %s", err)
+	}
+
+	if created.TemplateAuthor != "brainicorn" || created.TemplateDesc != "a beer template" {
+		t.Errorf("wrong template info: have (%s, %s)", created.TemplateAuthor, created.TemplateDesc)
+	}
+
+	kinds := []string{}
+	for _, v := range created.TemplateVariables {
+		kinds = append(kinds, reflect.TypeOf(v).Elem().Name())
+	}
+
+	expectedKinds := []string{"SimpleVar", "Selection", "ComplexVar", "MultiValue", "Computed"}
+	if !reflect.DeepEqual(expectedKinds, kinds) {
+		t.Errorf("wrong variables: have (%v), want (%v)", kinds, expectedKinds)
+	}
+
+	problems, err := NewDataProvider(nil).Lint(tmpDir, []string{filepath.Join(tmpDir, skelpTemplatesDirname)}, nil)
+
+	if err != nil || len(problems) > 0 {
+		t.Errorf("created skelplate should lint cleanly: %s %v", err, problems)
+	}
+
+	err = CreateSkelplate(tmpDir, descriptor)
+
+	if err == nil {
+		t.Errorf("creating a skelplate over an existing one should fail")
+	}
+}
+
+func TestCreateSkelplateKeepsReadme(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-new-test")
+	defer os.RemoveAll(tmpDir)
+
+	readmePath := filepath.Join(tmpDir, skelpTemplatesDirname, skelpReadmeFilename)
+	os.MkdirAll(filepath.Dir(readmePath), os.ModePerm)
+	ioutil.WriteFile(readmePath, []byte("# my beers\n"), 0644)
+
+	descriptor := extractDescriptor(ExtractOptions{Author: "brainicorn", Vars: []ExtractVar{{Name: "beer", Literal: "ipa"}}})
+
+	if err := CreateSkelplate(tmpDir, descriptor); err != nil {
+		t.Fatalf("error creating skelplate: %s", err)
+	}
+
+	readme, _ := ioutil.ReadFile(readmePath)
+
+	if string(readme) != "# my beers\n" {
+		t.Errorf("existing readme was overwritten: have (%s)", readme)
+	}
+
+	if !skelputil.PathExists(filepath.Join(tmpDir, skelpFilename)) {
+		t.Errorf("skelp.json was not written")
+	}
+}
+
+func TestBuildObjectVariable(t *testing.T) {
+	core.DisableColor = true
+
+	term := prompter.NewScriptedTerminal(
+		"brainicorn", "a brewery template", "", "y",
+		"owners", "\x0e\x0e\x0e\x0e\x0e",
+		"first", "", "jane", "y",
+		"email", "", "", "n",
+		"y", "last", "first",
+		"n",
+	)
+
+	builder := NewSkelplateBuilder()
+	builder.SetTerminal(term)
+
+	descriptor, err := builder.BuildDescriptor()
+
+	if err != nil {
+		t.Fatalf("error building descriptor: %s", err)
+	}
+
+	if term.Remaining() != 0 {
+		t.Errorf("%d keystrokes were not read", term.Remaining())
+	}
+
+	if !strings.Contains(term.Output(), "last is not a variable of owners") {
+		t.Errorf("a key that isn't a nested variable should be rejected: %s", term.Output())
+	}
+
+	if len(descriptor.TemplateVariables) != 1 {
+		t.Fatalf("wrong number of variables: have (%d) want (1)", len(descriptor.TemplateVariables))
+	}
+
+	ov, ok := descriptor.TemplateVariables[0].(*ObjectVar)
+
+	if !ok {
+		t.Fatalf("wrong variable: have (%T) want (*ObjectVar)", descriptor.TemplateVariables[0])
+	}
+
+	nested := []string{}
+	for _, v := range ov.Variables {
+		nested = append(nested, v.Name())
+	}
+
+	if ov.Name() != "owners" || !ov.Repeated || ov.Key != "first" || !reflect.DeepEqual(nested, []string{"first", "email"}) {
+		t.Errorf("wrong object: have (%s %t %s %v)", ov.Name(), ov.Repeated, ov.Key, nested)
+	}
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-new-test")
+	defer os.RemoveAll(tmpDir)
+
+	if err = CreateSkelplate(tmpDir, descriptor); err != nil {
+		t.Fatalf("error creating skelplate: %s", err)
+	}
+
+	problems, err := NewDataProvider(nil).Lint(tmpDir, []string{filepath.Join(tmpDir, skelpTemplatesDirname)}, nil)
+
+	if err != nil || len(problems) > 0 {
+		t.Errorf("created skelplate should lint cleanly: %s %v", err, problems)
+	}
+}
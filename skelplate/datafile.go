@@ -0,0 +1,69 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ReadDataFile reads the data for a template from a json file, or a yaml file when the file ends
+// in .yaml or .yml. Yaml values are converted to the types json would have given.
+func ReadDataFile(path string) (map[string]interface{}, error) {
+	var err error
+	var rawData []byte
+	var data map[string]interface{}
+
+	rawData, err = ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var yamlData interface{}
+		err = yaml.Unmarshal(rawData, &yamlData)
+
+		if err == nil && yamlData != nil {
+			var ok bool
+			if data, ok = jsonValueFor(yamlData).(map[string]interface{}); !ok {
+				err = fmt.Errorf("%s does not hold an object", path)
+			}
+		}
+	default:
+		err = json.Unmarshal(rawData, &data)
+	}
+
+	return data, err
+}
+
+func jsonValueFor(val interface{}) interface{} {
+	switch tval := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, v := range tval {
+			m[fmt.Sprintf("%v", k)] = jsonValueFor(v)
+		}
+
+		return m
+	case []interface{}:
+		list := []interface{}{}
+		for _, v := range tval {
+			list = append(list, jsonValueFor(v))
+		}
+
+		return list
+	case int:
+		return float64(tval)
+	case int64:
+		return float64(tval)
+	case uint64:
+		return float64(tval)
+	}
+
+	return val
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"text/template"
 
+	"github.com/brainicorn/skelp/executor"
+	"github.com/brainicorn/skelp/prompter"
+	"github.com/brainicorn/skelp/provider"
 	"github.com/brainicorn/skelp/skelputil"
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -22,139 +25,508 @@ const (
 
 type SkelplateDataProvider struct {
 	data         map[string]interface{}
+	shared       map[string]interface{}
+	kept         map[string]interface{}
+	includes     map[string][]provider.Include
+	parents      map[string]string
+	recorded     map[string]interface{}
 	funcMap      map[string]interface{}
 	tOptions     []string
+	outputDir    string
+	useDefaults  bool
+	review       bool
+	strict       bool
+	strictDesc   bool
 	beforePrompt func()
+	terminal     prompter.Terminal
+	prompts      PromptProvider
+	sections     *sectionTracker
+	progress     progress
 }
 
 func NewDataProvider(data map[string]interface{}) *SkelplateDataProvider {
 	return &SkelplateDataProvider{
 		data:     data,
+		includes: make(map[string][]provider.Include),
+		parents:  make(map[string]string),
+		recorded: make(map[string]interface{}),
 		funcMap:  skelputil.FunctionMap(),
 		tOptions: skelputil.TemplateOptions(),
 	}
 }
 
+// UseDefaults makes the provider take the default of every variable that isn't provided instead of
+// asking for it. Repeated objects that aren't provided get a single object of defaults.
+func (sdp *SkelplateDataProvider) UseDefaults() {
+	sdp.useDefaults = true
+}
+
+// UseStrictMode makes default values, variable names and the template files of every template fail
+// on keys missing from the data, as if each descriptor set strict. See StrictProviderFunc.
+func (sdp *SkelplateDataProvider) UseStrictMode() {
+	sdp.strict = true
+}
+
+// ReviewAnswers makes the provider list the answers once it has asked for any, so the user can
+// change them before the template is generated.
+func (sdp *SkelplateDataProvider) ReviewAnswers() {
+	sdp.review = true
+}
+
+// SetFuncs adds funcs to the functions available to the descriptor's default values, variable names,
+// choices and section conditions. They take precedence over the skelp and sprig functions with the
+// same names. An error is returned when funcs can't be used by a template.
+// It can be used as the generator's FuncsReceiver to get the generator's functions.
+func (sdp *SkelplateDataProvider) SetFuncs(funcs map[string]interface{}) error {
+	if err := skelputil.CheckFuncs(funcs); err != nil {
+		return err
+	}
+
+	sdp.funcMap = skelputil.FunctionMapWith(funcs)
+
+	return nil
+}
+
+// SetTerminal makes the provider ask its questions on term instead of stdin and stdout.
+func (sdp *SkelplateDataProvider) SetTerminal(term prompter.Terminal) {
+	sdp.terminal = term
+}
+
+// SetPromptProvider makes the provider ask pp for the values of variables instead of asking on the terminal.
+func (sdp *SkelplateDataProvider) SetPromptProvider(pp PromptProvider) {
+	sdp.prompts = pp
+}
+
+// SetOutputDir tells the provider where the template is being applied.
+// Path variables marked as relativeToOutput are checked against this directory.
+func (sdp *SkelplateDataProvider) SetOutputDir(dir string) {
+	sdp.outputDir = dir
+}
+
 func (sdp *SkelplateDataProvider) DataProviderFunc(templateRoot string) (interface{}, error) {
 	var err error
 	var data map[string]interface{}
-	var descriptorBytes []byte
 	var skelplate SkelplateDescriptor
-	var schemaValidationResult *gojsonschema.Result
 
-	jsonPath := filepath.Join(templateRoot, skelpFilename)
-	if !skelputil.PathExists(jsonPath) {
-		err = fmt.Errorf(ErrSkelpFileNotFound, jsonPath)
+	skelplate, err = sdp.readDescriptor(templateRoot)
+
+	if err == nil {
+		sdp.strictDesc = skelplate.Strict
+	}
+
+	recordedBefore := make(map[string]bool)
+	for name := range sdp.recorded {
+		recordedBefore[name] = true
 	}
 
 	if err == nil {
-		descriptorBytes, err = ioutil.ReadFile(jsonPath)
+		data, err = sdp.gatherData(skelplate)
+
+		if mke, ok := err.(*missingKeyError); ok {
+			err = fmt.Errorf(errMissingKeyAt, skelpFilename, lineForVariable(descriptorBytes, mke.varname), mke)
+		}
+	}
+
+	if err == nil && sdp.review && sdp.progress.asked > 0 {
+		data, err = sdp.reviewData(skelplate, data, recordedBefore)
 	}
 
 	if err == nil {
-		schemaLoader := gojsonschema.NewStringLoader(GithubComBrainicornSkelpSkelplateSkelplateDescriptor)
-		docLoader := gojsonschema.NewBytesLoader(descriptorBytes)
+		sdp.includes[templateRoot], err = sdp.resolveIncludes(skelplate.Includes, data)
+	}
 
-		schemaValidationResult, err = gojsonschema.Validate(schemaLoader, docLoader)
+	return data, err
+}
 
-		if err == nil && len(schemaValidationResult.Errors()) > 0 {
-			var errBuf bytes.Buffer
-			errBuf.WriteString("Error validating skelp descriptor:\n")
-			for _, re := range schemaValidationResult.Errors() {
-				errBuf.WriteString(fmt.Sprintf("  - %s\n", re))
-			}
+// readDescriptor reads the descriptor at templateRoot merged with the descriptors it extends.
+// The descriptor is checked against the schema first since it can't be unmarshalled safely otherwise.
+func (sdp *SkelplateDataProvider) readDescriptor(templateRoot string) (SkelplateDescriptor, error) {
+	var err error
+	var descriptorBytes []byte
+	var descriptor SkelplateDescriptor
+	var schemaViolations []string
 
-			err = errors.New(errBuf.String())
-		}
+	descriptorBytes, err = sdp.descriptorBytes(templateRoot)
+
+	if err == nil {
+		schemaViolations, err = validateDescriptorSchema(descriptorBytes)
+	}
+
+	if err == nil && len(schemaViolations) > 0 {
+		err = newSchemaError(schemaViolations)
 	}
 
 	if err == nil {
-		err = json.Unmarshal(descriptorBytes, &skelplate)
+		err = json.Unmarshal(descriptorBytes, &descriptor)
 	}
 
+	return descriptor, err
+}
+
+// validateDescriptorSchema checks a descriptor against the skelp.json schema and returns a message
+// for every violation.
+func validateDescriptorSchema(descriptorBytes []byte) ([]string, error) {
+	var err error
+	var schemaValidationResult *gojsonschema.Result
+
+	violations := []string{}
+	schemaLoader := gojsonschema.NewStringLoader(GithubComBrainicornSkelpSkelplateSkelplateDescriptor)
+	docLoader := gojsonschema.NewBytesLoader(descriptorBytes)
+
+	schemaValidationResult, err = gojsonschema.Validate(schemaLoader, docLoader)
+
 	if err == nil {
-		data, err = sdp.gatherData(skelplate)
+		for _, re := range schemaValidationResult.Errors() {
+			violations = append(violations, re.String())
+		}
 	}
 
-	return data, err
+	return violations, err
+}
+
+func newSchemaError(violations []string) error {
+	var errBuf bytes.Buffer
+
+	errBuf.WriteString("Error validating skelp descriptor:\n")
+	for _, v := range violations {
+		errBuf.WriteString(fmt.Sprintf("  - %s\n", v))
+	}
+
+	return errors.New(errBuf.String())
+}
+
+// IncludesProviderFunc returns the templates included by the template at templateRoot.
+// The includes are resolved when DataProviderFunc gathers the data for the template and each one
+// gets a data provider that shares the answers gathered so far.
+func (sdp *SkelplateDataProvider) IncludesProviderFunc(templateRoot string) ([]provider.Include, error) {
+	return sdp.includes[templateRoot], nil
+}
+
+// resolveIncludes renders the dir and data templates of each include against the gathered data.
+func (sdp *SkelplateDataProvider) resolveIncludes(includes []Include, data map[string]interface{}) ([]provider.Include, error) {
+	resolved := []provider.Include{}
+
+	for _, inc := range includes {
+		dir, err := sdp.runStringTemplate(inc.Dir, data)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse include dir template: %s - %s", inc.Dir, err)
+		}
+
+		if err = executor.CheckIncludeDir(sdp.outputDir, inc.TemplateID, dir); err != nil {
+			return nil, err
+		}
+
+		shared := make(map[string]interface{})
+		for k, v := range data {
+			shared[k] = v
+		}
+
+		for k, v := range inc.Data {
+			shared[k], err = sdp.runStringTemplate(v, data)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse include data template: %s - %s", v, err)
+			}
+		}
+
+		// the include's data mapping takes precedence over the data provided for the including template
+		provided := make(map[string]interface{})
+		for k, v := range sdp.data {
+			if _, isMapped := inc.Data[k]; !isMapped {
+				provided[k] = v
+			}
+		}
+
+		incProvider := &SkelplateDataProvider{
+			data:         provided,
+			shared:       shared,
+			includes:     sdp.includes,
+			parents:      sdp.parents,
+			recorded:     sdp.recorded,
+			funcMap:      sdp.funcMap,
+			tOptions:     sdp.tOptions,
+			outputDir:    filepath.Join(sdp.outputDir, dir),
+			useDefaults:  sdp.useDefaults,
+			review:       sdp.review,
+			strict:       sdp.strict,
+			beforePrompt: sdp.beforePrompt,
+			terminal:     sdp.terminal,
+			prompts:      sdp.prompts,
+		}
+
+		resolved = append(resolved, provider.Include{
+			TemplateID:   inc.TemplateID,
+			Dir:          dir,
+			DataProvider: incProvider.DataProviderFunc,
+		})
+	}
+
+	return resolved, nil
 }
 
 func (sdp *SkelplateDataProvider) gatherData(descriptor SkelplateDescriptor) (map[string]interface{}, error) {
-	var err error
+	fillerData := builtinData(descriptor)
+	violations := sdp.checkProvidedData(descriptor)
+
+	if len(violations) > 0 {
+		return nil, newProvidedDataError(violations)
+	}
+
+	sdp.sections = newSectionTracker(descriptor.Sections)
+	answered := sdp.shared
+	if len(sdp.kept) > 0 {
+		answered = make(map[string]interface{})
+		for _, m := range []map[string]interface{}{sdp.shared, sdp.kept} {
+			for k, v := range m {
+				answered[k] = v
+			}
+		}
+	}
+
+	sdp.progress = progress{total: countQuestions(descriptor.TemplateVariables, sdp.data, answered)}
+
+	varnames, err := sdp.gatherVariables(descriptor.TemplateVariables, "", fillerData, sdp.data, sdp.shared, &violations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(violations) > 0 {
+		return nil, newProvidedDataError(violations)
+	}
+
+	sdp.record(descriptor.TemplateVariables, varnames, fillerData)
+
+	return fillerData, err
+}
+
+// checkProvidedData validates the provided data before anything is asked, so a bad data file is
+// reported before the user answers any prompt. Defaults stand in for the answers, values that
+// depend on the answers are checked again when they're gathered.
+func (sdp *SkelplateDataProvider) checkProvidedData(descriptor SkelplateDescriptor) []string {
+	violations := []string{}
+
+	if len(sdp.data) < 1 {
+		return violations
+	}
 
-	fillerData := make(map[string]interface{})
+	useDefaults := sdp.useDefaults
+	sdp.useDefaults = true
+	sdp.sections = newSectionTracker(descriptor.Sections)
 
-	fillerData["TemplateAuthor"] = descriptor.TemplateAuthor
-	fillerData["TemplateRepo"] = descriptor.TemplateRepo
-	fillerData["TemplateCreated"] = descriptor.TemplateCreated
-	fillerData["TemplateModified"] = descriptor.TemplateModified
-	fillerData["TemplateDesc"] = descriptor.TemplateDesc
+	// errors are reported by the gathering that follows
+	sdp.gatherVariables(descriptor.TemplateVariables, "", builtinData(descriptor), sdp.data, sdp.shared, &violations)
 
-	for _, v := range descriptor.TemplateVariables {
+	sdp.useDefaults = useDefaults
+
+	return violations
+}
+
+// builtinData returns the Template* values every template can use.
+func builtinData(descriptor SkelplateDescriptor) map[string]interface{} {
+	return map[string]interface{}{
+		"TemplateAuthor":   descriptor.TemplateAuthor,
+		"TemplateRepo":     descriptor.TemplateRepo,
+		"TemplateCreated":  descriptor.TemplateCreated,
+		"TemplateModified": descriptor.TemplateModified,
+		"TemplateDesc":     descriptor.TemplateDesc,
+	}
+}
+
+// gatherVariables fills scope with a value for each variable and returns the rendered variable names.
+// Templates are run against scope, so later variables can use earlier ones, and values found in
+// provided or shared are used instead of prompting. The prefix qualifies names in questions and messages.
+func (sdp *SkelplateDataProvider) gatherVariables(vars []TemplateVariable, prefix string, scope, provided, shared map[string]interface{}, violations *[]string) ([]string, error) {
+	varnames := []string{}
+
+	for _, v := range vars {
 		var dataval interface{}
 		var gotdata bool
 		var defval interface{}
-		varname, err := sdp.runStringTemplate(v.Name(), fillerData)
+		varname, err := sdp.runStringTemplate(v.Name(), scope)
 
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse variable name template: %s - %s", v.Name(), err)
+			return nil, missingKey(v, fmt.Errorf("unable to parse variable name template: %s - %s", v.Name(), err))
 		}
 
-		valOfDefault := reflect.ValueOf(v.Default())
+		varnames = append(varnames, varname)
+		qualifiedName := prefix + varname
+		skipped := false
 
-		if valOfDefault.Kind() == reflect.String {
-			defval, err = sdp.runStringTemplate(v.Default().(string), fillerData)
+		// sections group top level variables, nested variables belong to the section of their object
+		if prefix == "" {
+			skipped, err = sdp.enterSection(v, scope)
 
 			if err != nil {
-				return nil, fmt.Errorf("unable to parse variable default template: %s - %s", v.Default(), err)
-			}
-		} else if isStringSlice(valOfDefault) {
-			defOpts := v.Default().([]interface{})
-			defVals := []interface{}{}
-			for _, ds := range defOpts {
-				dv, dverr := sdp.runStringTemplate(ds.(string), fillerData)
-				if dverr != nil {
-					return nil, fmt.Errorf("unable to parse variable default template: %s - %s", ds, dverr)
-				}
-				defVals = append(defVals, dv)
+				return nil, err
+			}
+
+			if skipped {
+				sdp.progress.total -= countQuestions([]TemplateVariable{v}, provided, shared)
+			}
+		}
+
+		// answers kept while reviewing are already typed
+		if keptVal, isKept := sdp.kept[varname]; isKept && prefix == "" {
+			scope[varname] = keptVal
+			continue
+		}
+
+		if ov, ok := v.(*ObjectVar); ok {
+			useDefaults := sdp.useDefaults
+			sdp.useDefaults = useDefaults || skipped
+
+			scope[varname], err = sdp.gatherObject(ov, qualifiedName, scope, provided[varname], violations)
+			sdp.useDefaults = useDefaults
+
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		defval, err = sdp.renderDefault(v, scope)
+
+		if err != nil {
+			return nil, missingKey(v, err)
+		}
+
+		if sel, ok := v.(*Selection); ok {
+			v, err = sdp.resolveChoices(sel, scope)
+
+			if err != nil {
+				return nil, missingKey(v, fmt.Errorf("unable to parse variable choices template: %s - %s", qualifiedName, err))
+			}
+		}
+
+		vtype := dataTypeFor(v, defval)
+
+		if computed, ok := v.(*Computed); ok {
+			_, isProvided := provided[varname]
+
+			if isProvided && !computed.Overridable {
+				*violations = append(*violations, fmt.Sprintf("%s: %s", qualifiedName, errComputedOverride))
 			}
-			defval = defVals
 
-		} else {
-			defval = v.Default()
+			if !isProvided || !computed.Overridable {
+				scope[varname], err = convertValue(stringForValue(defval), vtype, DefaultDateLayout)
+
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert computed value: %s - %s", qualifiedName, err)
+				}
+
+				continue
+			}
 		}
 
-		if dataval, gotdata = sdp.data[varname]; gotdata {
+		if dataval, gotdata = provided[varname]; gotdata {
 			fillerVal := dataval
-			typeOfDefval := reflect.TypeOf(defval)
-			typeOfDataval := reflect.TypeOf(dataval)
-			if typeOfDefval.Kind() == typeOfDataval.Kind() {
-				if typeOfDataval.Kind() == reflect.String {
-					fillerVal, err = sdp.runStringTemplate(dataval.(string), fillerData)
-
-					if err != nil {
-						return nil, fmt.Errorf("unable to parse data template: %s - %s", dataval, err)
-					}
+
+			if datastring, ok := dataval.(string); ok {
+				fillerVal, err = sdp.runStringTemplate(datastring, scope)
+
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse data template: %s - %s", dataval, err)
 				}
+			}
 
-				fillerData[varname] = fillerVal
+			if !providedTypeMatches(fillerVal, vtype, defval) {
+				*violations = append(*violations, fmt.Sprintf("invalid type for provided data entry '%s': want (%s) have (%s)", qualifiedName, vtype, reflect.TypeOf(dataval).Kind()))
+				scope[varname] = defval
 				continue
-			} else {
-				return nil, fmt.Errorf("invalid type for provided data entry '%s': want (%s) have (%s)", varname, typeOfDataval.Kind(), typeOfDefval.Kind())
 			}
+
+			varViolations := validateProvidedValue(v, qualifiedName, vtype, fillerVal, defval, sdp.outputDir)
+
+			if len(varViolations) < 1 {
+				fillerVal, err = convertProvidedValue(fillerVal, vtype, dateLayoutFor(complexVarFor(v)), defval)
+
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert provided data entry '%s': %s", qualifiedName, err)
+				}
+			}
+
+			*violations = append(*violations, varViolations...)
+			scope[varname] = fillerVal
+			continue
 		}
 
-		dataval, err = promptForVariable(v, varname, defval, sdp.beforePrompt)
+		// answers shared by an including template are already typed unless they came from its data mapping
+		if sharedVal, isShared := shared[varname]; isShared {
+			if sharedString, ok := sharedVal.(string); ok {
+				sharedVal, err = convertValue(sharedString, vtype, dateLayoutFor(complexVarFor(v)))
+
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert shared data entry '%s': %s", qualifiedName, err)
+				}
+			}
+
+			scope[varname] = sharedVal
+			continue
+		}
+
+		// once the provided data is known to be bad there's no point in asking for the rest,
+		// keep going with the defaults so that every violation gets reported.
+		if len(*violations) > 0 {
+			scope[varname] = defval
+			continue
+		}
+
+		if sdp.useDefaults || skipped {
+			scope[varname] = typedDefault(v, vtype, defval)
+			continue
+		}
+
+		vp := newVariablePrompt(v, qualifiedName, defval, sdp.outputDir)
+		sdp.describePosition(&vp, true)
+
+		dataval, err = sdp.promptProvider().PromptForVariable(vp)
 
 		if err != nil {
-			return nil, fmt.Errorf("error asking for input: (%s): %s", varname, err)
+			return nil, fmt.Errorf("error asking for input: (%s): %s", qualifiedName, err)
+		}
+
+		if err = checkAnswer(vp, dataval); err != nil {
+			return nil, err
 		}
 
-		fillerData[varname] = dataval
+		scope[varname] = dataval
 
 	}
 
-	return fillerData, err
+	return varnames, nil
+}
+
+// renderDefault runs the default of the variable, or each entry of a list default, as a template against scope.
+func (sdp *SkelplateDataProvider) renderDefault(v TemplateVariable, scope map[string]interface{}) (interface{}, error) {
+	valOfDefault := reflect.ValueOf(v.Default())
+
+	if valOfDefault.Kind() == reflect.String {
+		defval, err := sdp.runStringTemplate(v.Default().(string), scope)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse variable default template: %s - %s", v.Default(), err)
+		}
+
+		return defval, nil
+	}
+
+	if isStringSlice(valOfDefault) {
+		defVals := []interface{}{}
+		for _, ds := range v.Default().([]interface{}) {
+			dv, err := sdp.runStringTemplate(ds.(string), scope)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse variable default template: %s - %s", ds, err)
+			}
+			defVals = append(defVals, dv)
+		}
+
+		return defVals, nil
+	}
+
+	return v.Default(), nil
 }
 
 func (sdp *SkelplateDataProvider) runStringTemplate(input string, tmplData interface{}) (string, error) {
@@ -168,7 +540,7 @@ func (sdp *SkelplateDataProvider) runStringTemplate(input string, tmplData inter
 	}
 
 	if err == nil {
-		inputTmpl, err = template.New("nameOrDefault template").Option(sdp.tOptions...).Funcs(sdp.funcMap).Parse(input)
+		inputTmpl, err = template.New("nameOrDefault template").Option(sdp.templateOptions()...).Funcs(sdp.funcMap).Parse(input)
 	}
 
 	if err == nil {
@@ -182,6 +554,194 @@ func (sdp *SkelplateDataProvider) runStringTemplate(input string, tmplData inter
 	return target, err
 }
 
+// gatherObject gathers the nested variables of an object variable into a map.
+// Repeated objects are gathered into a list, or into a map when the object has a key.
+func (sdp *SkelplateDataProvider) gatherObject(ov *ObjectVar, qualifiedName string, scope map[string]interface{}, provided interface{}, violations *[]string) (interface{}, error) {
+	var err error
+	var obj map[string]interface{}
+
+	if !ov.Repeated {
+		var item map[string]interface{}
+
+		if provided != nil {
+			var ok bool
+			if item, ok = provided.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("invalid type for provided data entry '%s': want (object) have (%s)", qualifiedName, reflect.TypeOf(provided).Kind())
+			}
+		}
+
+		return sdp.gatherObjectItem(ov, qualifiedName+".", scope, item, violations)
+	}
+
+	objects := []interface{}{}
+
+	if provided != nil {
+		items, ok := providedObjectItems(provided, ov.Key)
+
+		if !ok {
+			return nil, fmt.Errorf("invalid type for provided data entry '%s': want (list of objects) have (%s)", qualifiedName, reflect.TypeOf(provided).Kind())
+		}
+
+		for i, item := range items {
+			obj, err = sdp.gatherObjectItem(ov, fmt.Sprintf("%s[%d].", qualifiedName, i), scope, item, violations)
+
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, obj)
+		}
+	} else {
+		again := true
+
+		for again {
+			obj, err = sdp.gatherObjectItem(ov, fmt.Sprintf("%s[%d].", qualifiedName, len(objects)), scope, nil, violations)
+
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, obj)
+
+			if len(*violations) > 0 || sdp.useDefaults {
+				break
+			}
+
+			again, err = sdp.askToAddAnother(ov, qualifiedName)
+
+			if err != nil {
+				return nil, fmt.Errorf("error asking for input: (%s): %s", qualifiedName, err)
+			}
+
+			if again {
+				sdp.progress.total += countQuestions(ov.Variables, nil, nil)
+			}
+		}
+	}
+
+	if !skelputil.IsBlank(ov.Key) {
+		keyed := make(map[string]interface{})
+		for _, o := range objects {
+			keyed[stringForValue(o.(map[string]interface{})[ov.Key])] = o
+		}
+
+		return keyed, nil
+	}
+
+	return objects, nil
+}
+
+// gatherObjectItem gathers a single object. The nested variables can use everything in the
+// enclosing scope but only the nested values end up in the object.
+func (sdp *SkelplateDataProvider) gatherObjectItem(ov *ObjectVar, prefix string, scope, provided map[string]interface{}, violations *[]string) (map[string]interface{}, error) {
+	itemScope := make(map[string]interface{})
+	for k, v := range scope {
+		itemScope[k] = v
+	}
+
+	varnames, err := sdp.gatherVariables(ov.Variables, prefix, itemScope, provided, nil, violations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	obj := make(map[string]interface{})
+	for _, name := range varnames {
+		obj[name] = itemScope[name]
+	}
+
+	return obj, nil
+}
+
+// providedObjectItems returns the objects in a provided list, or in a provided map when the
+// objects are keyed. Keyed objects get their key filled in if it's missing.
+func providedObjectItems(provided interface{}, key string) ([]map[string]interface{}, bool) {
+	items := []map[string]interface{}{}
+
+	if list, ok := provided.([]interface{}); ok {
+		for _, elem := range list {
+			item, isMap := elem.(map[string]interface{})
+			if !isMap {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+
+		return items, true
+	}
+
+	keyed, ok := provided.(map[string]interface{})
+	if !ok || skelputil.IsBlank(key) {
+		return nil, false
+	}
+
+	keys := []string{}
+	for k := range keyed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		item, isMap := keyed[k].(map[string]interface{})
+		if !isMap {
+			return nil, false
+		}
+
+		if _, hasKey := item[key]; !hasKey {
+			item[key] = k
+		}
+		items = append(items, item)
+	}
+
+	return items, true
+}
+
+// resolveChoices returns a copy of the selection with its choice templates rendered against the
+// data gathered so far.
+func (sdp *SkelplateDataProvider) resolveChoices(sel *Selection, tmplData interface{}) (*Selection, error) {
+	var err error
+	var rendered string
+
+	resolved := *sel
+	choices := []Choice{}
+
+	if !skelputil.IsBlank(sel.Choices.Template) {
+		rendered, err = sdp.runStringTemplate(sel.Choices.Template, tmplData)
+
+		if err == nil {
+			for _, val := range strings.Split(rendered, ",") {
+				if !skelputil.IsBlank(val) {
+					choices = append(choices, Choice{Value: strings.TrimSpace(val)})
+				}
+			}
+		}
+	}
+
+	for _, c := range sel.Choices.Options {
+		var rc Choice
+
+		if err == nil {
+			rc.Label, err = sdp.runStringTemplate(c.Label, tmplData)
+		}
+
+		if err == nil {
+			rc.Value, err = sdp.runStringTemplate(c.Value, tmplData)
+		}
+
+		if err == nil {
+			rc.Help, err = sdp.runStringTemplate(c.Help, tmplData)
+		}
+
+		if err == nil {
+			choices = append(choices, rc)
+		}
+	}
+
+	resolved.Choices = ChoiceList{Options: choices}
+
+	return &resolved, err
+}
+
 func isStringSlice(valOf reflect.Value) bool {
 	if valOf.Kind() == reflect.Slice {
 		if valOf.Len() > 0 {
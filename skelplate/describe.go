@@ -0,0 +1,169 @@
+package skelplate
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TemplateDescription is what a user needs to know about a template before applying it.
+type TemplateDescription struct {
+	Author      string                `json:"author"`
+	Description string                `json:"description"`
+	Repository  string                `json:"repository"`
+	Created     time.Time             `json:"created"`
+	Modified    time.Time             `json:"modified"`
+	Extends     string                `json:"extends,omitempty"`
+	Includes    []Include             `json:"includes,omitempty"`
+	Variables   []VariableDescription `json:"variables"`
+
+	// Files are the slash separated paths under the templates folder. Directories end with a slash.
+	Files []string `json:"files"`
+}
+
+// VariableDescription flattens the configuration of any kind of variable.
+type VariableDescription struct {
+	Name      string                `json:"name"`
+	Kind      string                `json:"kind"`
+	Type      string                `json:"type,omitempty"`
+	Default   interface{}           `json:"default,omitempty"`
+	Value     string                `json:"value,omitempty"`
+	Prompt    string                `json:"prompt,omitempty"`
+	Help      string                `json:"help,omitempty"`
+	DocsURL   string                `json:"docsUrl,omitempty"`
+	Required  bool                  `json:"required,omitempty"`
+	Password  bool                  `json:"password,omitempty"`
+	Min       float64               `json:"min,omitempty"`
+	Max       float64               `json:"max,omitempty"`
+	Choices   []Choice              `json:"choices,omitempty"`
+	Multiple  bool                  `json:"multiple,omitempty"`
+	MinPicks  int                   `json:"minPicks,omitempty"`
+	MaxPicks  int                   `json:"maxPicks,omitempty"`
+	Repeated  bool                  `json:"repeated,omitempty"`
+	Key       string                `json:"key,omitempty"`
+	Variables []VariableDescription `json:"variables,omitempty"`
+}
+
+// Describe reads the descriptor of the template at templateRoot, merged with any template it
+// extends, and lists the files of every template layer.
+func (sdp *SkelplateDataProvider) Describe(templateRoot string, templateDirs []string) (TemplateDescription, error) {
+	var err error
+	var extends string
+	var descriptor SkelplateDescriptor
+	var desc TemplateDescription
+
+	// merging drops extends so it's read from the template's own descriptor
+	extends, err = readExtends(templateRoot)
+
+	if err == nil {
+		descriptor, err = sdp.readDescriptor(templateRoot)
+	}
+
+	if err == nil {
+		desc = TemplateDescription{
+			Author:      descriptor.TemplateAuthor,
+			Description: descriptor.TemplateDesc,
+			Repository:  descriptor.TemplateRepo,
+			Created:     descriptor.TemplateCreated,
+			Modified:    descriptor.TemplateModified,
+			Extends:     extends,
+			Includes:    descriptor.Includes,
+			Variables:   describeVariables(descriptor.TemplateVariables),
+		}
+
+		desc.Files, err = templateFiles(templateDirs)
+	}
+
+	return desc, err
+}
+
+func describeVariables(vars []TemplateVariable) []VariableDescription {
+	descs := []VariableDescription{}
+
+	for _, v := range vars {
+		vd := VariableDescription{Name: v.Name(), Type: declaredType(v)}
+
+		switch tv := v.(type) {
+		case *SimpleVar:
+			vd.Kind = typeSimple
+			vd.Default = tv.DefaultVal
+		case *ComplexVar:
+			vd.Kind = typeComplex
+			describeComplex(&vd, tv)
+		case *MultiValue:
+			vd.Kind = typeMultiVal
+			describeComplex(&vd, &tv.ComplexVar)
+		case *Selection:
+			vd.Kind = typeSelect
+			describeComplex(&vd, &tv.ComplexVar)
+			vd.Choices = tv.Choices.Options
+			vd.Multiple = tv.MultipleChoice
+			vd.MinPicks = tv.MinPicks
+			vd.MaxPicks = tv.MaxPicks
+
+			if len(vd.Choices) < 1 && tv.Choices.Template != "" {
+				vd.Choices = []Choice{{Value: tv.Choices.Template}}
+			}
+		case *Computed:
+			vd.Kind = typeComputed
+			vd.Value = tv.Value
+		case *ObjectVar:
+			vd.Kind = typeObject
+			vd.Repeated = tv.Repeated
+			vd.Key = tv.Key
+			vd.Variables = describeVariables(tv.Variables)
+		}
+
+		descs = append(descs, vd)
+	}
+
+	return descs
+}
+
+func describeComplex(vd *VariableDescription, cv *ComplexVar) {
+	vd.Default = cv.DefaultVal
+	vd.Prompt = cv.Prompt
+	vd.Help = cv.Help
+	vd.DocsURL = cv.DocsURL
+	vd.Required = cv.Required
+	vd.Password = cv.Password
+	vd.Min = cv.Min
+	vd.Max = cv.Max
+}
+
+// templateFiles lists the files of all the template layers as one sorted tree.
+func templateFiles(templateDirs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	files := []string{}
+
+	for _, tmplDir := range templateDirs {
+		err := filepath.Walk(tmplDir, func(curPath string, fi os.FileInfo, werr error) error {
+			if werr != nil || curPath == tmplDir {
+				return werr
+			}
+
+			rel, _ := filepath.Rel(tmplDir, curPath)
+			rel = filepath.ToSlash(rel)
+
+			if fi.IsDir() {
+				rel += "/"
+			}
+
+			if !seen[rel] {
+				seen[rel] = true
+				files = append(files, rel)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
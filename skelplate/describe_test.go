@@ -0,0 +1,98 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const describeVarsJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "projectName", "default": "beer"},
+    {"name": "abv", "default": 5, "type": "float", "prompt": "How strong:", "help": "percent alcohol", "docsUrl": "https://beer.example/abv", "required": true, "min": 1, "max": 12},
+    {"name": "style", "default": "ipa", "choices": ["ipa", {"value": "stout", "label": "Stout"}], "mutlichoice": false},
+    {"name": "hopTypes", "default": ["citra"], "choices": ["citra", "mosaic", "simcoe"], "mutlichoice": true, "minPicks": 1, "maxPicks": 2},
+    {"name": "tags", "default": ["hoppy"], "mutlival": true},
+    {"name": "loud", "value": "{{.projectName | upper}}", "computed": true},
+    {"name": "hops", "repeated": true, "key": "hopName", "variables": [{"name": "hopName", "default": "citra"}]}
+  ]
+}`
+
+func TestDescribeVariables(t *testing.T) {
+	var descriptor SkelplateDescriptor
+
+	if err := json.Unmarshal([]byte(describeVarsJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	want := []VariableDescription{
+		{Name: "projectName", Kind: typeSimple, Default: "beer"},
+		{Name: "abv", Kind: typeComplex, Type: VarTypeFloat, Default: float64(5), Prompt: "How strong:", Help: "percent alcohol", DocsURL: "https://beer.example/abv", Required: true, Min: 1, Max: 12},
+		{Name: "style", Kind: typeSelect, Default: "ipa", Choices: []Choice{{Value: "ipa"}, {Value: "stout", Label: "Stout"}}},
+		{Name: "hopTypes", Kind: typeSelect, Default: []interface{}{"citra"}, Choices: []Choice{{Value: "citra"}, {Value: "mosaic"}, {Value: "simcoe"}}, Multiple: true, MinPicks: 1, MaxPicks: 2},
+		{Name: "tags", Kind: typeMultiVal, Default: []interface{}{"hoppy"}},
+		{Name: "loud", Kind: typeComputed, Value: "{{.projectName | upper}}"},
+		{Name: "hops", Kind: typeObject, Repeated: true, Key: "hopName", Variables: []VariableDescription{
+			{Name: "hopName", Kind: typeSimple, Default: "citra"},
+		}},
+	}
+
+	have := describeVariables(descriptor.TemplateVariables)
+
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("variable descriptions do not match:\nhave (%+v)\nwant (%+v)", have, want)
+	}
+}
+
+func TestDescribeExtends(t *testing.T) {
+	childRoot, _ := filepath.Abs("../testdata/generator/extends/child")
+	baseRoot, _ := filepath.Abs("../testdata/generator/extends/base")
+
+	dp := NewDataProvider(nil)
+	dp.parents[childRoot] = baseRoot
+
+	desc, err := dp.Describe(childRoot, []string{filepath.Join(childRoot, "templates"), filepath.Join(baseRoot, "templates")})
+
+	if err != nil {
+		t.Fatalf("error describing template: %s", err)
+	}
+
+	names := []string{}
+	for _, v := range desc.Variables {
+		names = append(names, v.Name)
+	}
+
+	if desc.Extends != "../base" {
+		t.Errorf("extends does not match: have (%s) want (../base)", desc.Extends)
+	}
+
+	if !reflect.DeepEqual(names, []string{"projectName", "license", "owner"}) {
+		t.Errorf("variables do not match: have (%v)", names)
+	}
+
+	if desc.Variables[1].Default != "Apache-2.0" || desc.Variables[1].Prompt != "Enter a license:" {
+		t.Errorf("overridden variable does not match: have (%+v)", desc.Variables[1])
+	}
+
+	if !reflect.DeepEqual(desc.Files, []string{"LICENSE.txt", "README.md"}) {
+		t.Errorf("files do not match: have (%v)", desc.Files)
+	}
+}
+
+func TestDescribeSchemaViolations(t *testing.T) {
+	root, _ := ioutil.TempDir("", "skelp-describe-test")
+	defer os.RemoveAll(root)
+
+	ioutil.WriteFile(filepath.Join(root, skelpFilename), []byte(`{"author": 5, "variables": []}`), 0644)
+
+	_, err := NewDataProvider(nil).Describe(root, []string{})
+
+	if err == nil || !strings.HasPrefix(err.Error(), "Error validating skelp descriptor") {
+		t.Errorf("wrong error: have (%v) want a schema error", err)
+	}
+}
@@ -3,6 +3,8 @@ package skelplate
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/brainicorn/skelp/skelputil"
 )
 
 const (
@@ -10,6 +12,21 @@ const (
 	typeMultiVal = "multival"
 	typeComplex  = "complex"
 	typeSelect   = "select"
+	typeComputed = "computed"
+	typeObject   = "object"
+)
+
+// The data types a variable can declare with its type field.
+const (
+	VarTypeString = "string"
+	VarTypeInt    = "int"
+	VarTypeFloat  = "float"
+	VarTypeBool   = "bool"
+	VarTypeDate   = "date"
+	VarTypePath   = "path"
+
+	// DefaultDateLayout is the layout used to parse date variables that don't provide one.
+	DefaultDateLayout = "2006-01-02"
 )
 
 type SkelplateDescriptor struct {
@@ -30,6 +47,64 @@ type SkelplateDescriptor struct {
 
 	// TemplateVariables holds the variables and their configuration for processing a template.
 	TemplateVariables []TemplateVariable `json:"variables"`
+
+	// Includes are other templates that are applied along with this template.
+	Includes []Include `json:"includes,omitempty"`
+
+	// Extends is the path, repository url or alias of a template this template builds on.
+	// The parent's variables and templates are used unless this template overrides them.
+	Extends string `json:"extends,omitempty"`
+
+	// Sections group variables under a title that is displayed before their prompts.
+	Sections []Section `json:"sections,omitempty"`
+
+	// Strict makes templates fail on keys missing from the data instead of rendering empty values.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// Section groups top level variables that are asked for together.
+// The variables of a section should be next to each other in the variables array.
+//
+// @jsonSchema(additionalProperties=false)
+type Section struct {
+
+	// Title is displayed before the prompts of the section's variables.
+	//
+	// @jsonSchema(required=true)
+	Title string `json:"title"`
+
+	// Description is displayed under the title.
+	Description string `json:"description,omitempty"`
+
+	// When is a golang template run against the data gathered before the section's first variable.
+	// If it renders false or blank the section's variables are not prompted and take their defaults.
+	When string `json:"when,omitempty"`
+
+	// Variables are the names of the variables in the section.
+	//
+	// @jsonSchema(required=true)
+	Variables []string `json:"variables"`
+}
+
+// Include is another template that gets applied after this template.
+// Answers already gathered for this template are shared with the included template.
+//
+// @jsonSchema(additionalProperties=false)
+type Include struct {
+
+	// TemplateID is the path, repository url or alias of the included template.
+	// Relative paths are resolved against the including template.
+	//
+	// @jsonSchema(required=true)
+	TemplateID string `json:"template"`
+
+	// Dir is the sub directory of the output directory to apply the included template to.
+	// The dir can be a golang template.
+	Dir string `json:"dir,omitempty"`
+
+	// Data maps variable names of the included template to golang templates that are run
+	// against the data gathered for this template.
+	Data map[string]string `json:"data,omitempty"`
 }
 
 // TemplateVariable is the base interface for a variable
@@ -38,13 +113,29 @@ type SkelplateDescriptor struct {
 // 	anyOf=["github.com/brainicorn/skelp/skelplate/SimpleVar"
 //	,"github.com/brainicorn/skelp/skelplate/ComplexVar"
 //	,"github.com/brainicorn/skelp/skelplate/Selection"
-//	,"github.com/brainicorn/skelp/skelplate/MultiValue"]
+//	,"github.com/brainicorn/skelp/skelplate/MultiValue"
+//	,"github.com/brainicorn/skelp/skelplate/Computed"
+//	,"github.com/brainicorn/skelp/skelplate/ObjectVar"]
 // )
 type TemplateVariable interface {
 	Name() string
 	Default() interface{}
 }
 
+// typedVariable is implemented by variables that can declare a data type.
+type typedVariable interface {
+	Type() string
+}
+
+// declaredType returns the data type declared by the variable, or "" when it doesn't declare one.
+func declaredType(tvar TemplateVariable) string {
+	if tv, ok := tvar.(typedVariable); ok {
+		return tv.Type()
+	}
+
+	return ""
+}
+
 // SimpleVar is an object that can express a name value pair
 //
 // @jsonSchema(additionalProperties=false)
@@ -61,6 +152,12 @@ type SimpleVar struct {
 	//
 	// @jsonSchema(required=true, type=["string","number","integer","boolean","array"])
 	DefaultVal interface{} `json:"default"`
+
+	// DataType is the type of value the variable holds.
+	// When omitted the type is inferred from the default value.
+	//
+	// @jsonSchema(enum=["int","float","bool","string","date","path"])
+	DataType string `json:"type,omitempty"`
 }
 
 func (sv *SimpleVar) Name() string {
@@ -71,6 +168,10 @@ func (sv *SimpleVar) Default() interface{} {
 	return sv.DefaultVal
 }
 
+func (sv *SimpleVar) Type() string {
+	return sv.DataType
+}
+
 // ComplexVar applies restrictions to input.
 //
 // @jsonSchema(additionalProperties=false)
@@ -83,6 +184,12 @@ type ComplexVar struct {
 	// Prompt the string to display when asking for a value.
 	Prompt string `json:"prompt,omitempty"`
 
+	// Help is displayed when the user asks for help while answering the prompt.
+	Help string `json:"help,omitempty"`
+
+	// DocsURL points at documentation for the variable and is displayed with the help.
+	DocsURL string `json:"docsUrl,omitempty"`
+
 	// Min the minimum value (for numbers) or length (for strings).
 	Min float64 `json:"min,omitempty"`
 
@@ -91,6 +198,23 @@ type ComplexVar struct {
 
 	// Password is a flag to turn on input masking for hiding passwords
 	Password bool `json:"password"`
+
+	// Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a
+	// blank line when neither is set.
+	Multiline bool `json:"multiline,omitempty"`
+
+	// Layout is the golang time layout used to parse date variables (defaults to 2006-01-02).
+	Layout string `json:"layout,omitempty"`
+
+	// MustExist requires a path variable to point at an existing file or directory.
+	MustExist bool `json:"exists,omitempty"`
+
+	// IsDir requires a path variable to point at a directory if it exists.
+	IsDir bool `json:"isDir,omitempty"`
+
+	// RelativeToOutput resolves relative path variables against the output directory instead of
+	// the working directory when checking them.
+	RelativeToOutput bool `json:"relativeToOutput,omitempty"`
 }
 
 // Selection represents a configurable "select box".
@@ -106,8 +230,192 @@ type Selection struct {
 	MultipleChoice bool `json:"mutlichoice"`
 
 	// Choices are the options to display in a select box.
+	// Choices can be plain strings, choice objects or a template string that renders a comma
+	// separated list of values.
+	//
+	// @jsonSchema(required=true, type=["array","string"])
+	Choices ChoiceList `json:"choices,omitempty"`
+
+	// MinPicks is the minimum number of choices that must be picked in a multiple choice selection.
+	MinPicks int `json:"minPicks,omitempty"`
+
+	// MaxPicks is the maximum number of choices that can be picked in a multiple choice selection.
+	MaxPicks int `json:"maxPicks,omitempty"`
+}
+
+// Computed is a variable whose value is derived from the variables before it.
+// Computed variables are never prompted for.
+//
+// @jsonSchema(additionalProperties=false)
+type Computed struct {
+
+	// Name is the name of the variable.
+	// The name can be a golang template and can use values gathered from previous
+	// variables in the variables array.
+	//
+	// @jsonSchema(required=true)
+	Varname string `json:"name,omitempty"`
+
+	// Value is a golang template that computes the value from previous variables.
+	//
+	// @jsonSchema(required=true)
+	Value string `json:"value"`
+
+	// IsComputed designates the variable as computed.
+	//
+	// @jsonSchema(required=true)
+	IsComputed bool `json:"computed"`
+
+	// DataType is the type the computed value is converted to (defaults to string).
+	//
+	// @jsonSchema(enum=["int","float","bool","string","date","path"])
+	DataType string `json:"type,omitempty"`
+
+	// Overridable allows the value to be supplied by a data file instead of being computed.
+	Overridable bool `json:"overridable,omitempty"`
+}
+
+func (c *Computed) Name() string {
+	return c.Varname
+}
+
+func (c *Computed) Default() interface{} {
+	return c.Value
+}
+
+func (c *Computed) Type() string {
+	return c.DataType
+}
+
+// ObjectVar gathers a group of nested variables into a single object.
+// Repeated objects ask whether another should be added, like MultiValue does, and are gathered
+// into a list, or into a map when a key is given.
+//
+// @jsonSchema(additionalProperties=false)
+type ObjectVar struct {
+
+	// Name is the name of the variable.
+	// The name can be a golang template and can use values gathered from previous
+	// variables in the variables array.
+	//
+	// @jsonSchema(required=true)
+	Varname string `json:"name,omitempty"`
+
+	// Variables are the nested variables that make up the object.
+	// Nested variables can use the values of the enclosing variables in their templates.
+	//
+	// @jsonSchema(required=true)
+	Variables []TemplateVariable `json:"variables"`
+
+	// Repeated allows the user to enter multiple objects.
+	Repeated bool `json:"repeated,omitempty"`
+
+	// AddPrompt is the string to display when asking if another object should be entered.
+	AddPrompt string `json:"addPrompt,omitempty"`
+
+	// Key is the name of a nested variable used to key repeated objects into a map.
+	Key string `json:"key,omitempty"`
+}
+
+func (ov *ObjectVar) Name() string {
+	return ov.Varname
+}
+
+func (ov *ObjectVar) Default() interface{} {
+	return nil
+}
+
+// Choice is a single option in a select box.
+//
+// @jsonSchema(additionalProperties=false)
+type Choice struct {
+	// Label is the text displayed for the choice (defaults to the value).
+	Label string `json:"label,omitempty"`
+
+	// Value is what templates receive when the choice is selected.
+	//
 	// @jsonSchema(required=true)
-	Choices []string `json:"choices,omitempty"`
+	Value string `json:"value"`
+
+	// Help is a short description displayed when the choice has focus.
+	Help string `json:"help,omitempty"`
+}
+
+// DisplayLabel returns the label of the choice or its value if there's no label.
+func (c Choice) DisplayLabel() string {
+	if skelputil.IsBlank(c.Label) {
+		return c.Value
+	}
+
+	return c.Label
+}
+
+// ChoiceList holds the options of a Selection.
+// Either Options holds the choices or Template holds a template string that renders a comma
+// separated list of values using the data gathered so far.
+type ChoiceList struct {
+	Options  []Choice
+	Template string
+}
+
+// Values returns the value of each option.
+func (cl ChoiceList) Values() []string {
+	vals := []string{}
+	for _, c := range cl.Options {
+		vals = append(vals, c.Value)
+	}
+
+	return vals
+}
+
+// UnmarshalJSON reads either a template string or an array of strings and/or choice objects.
+func (cl *ChoiceList) UnmarshalJSON(data []byte) error {
+	var err error
+	var items []json.RawMessage
+
+	if err = json.Unmarshal(data, &cl.Template); err == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(data, &items)
+
+	for _, item := range items {
+		var val string
+		var choice Choice
+
+		if err == nil {
+			if json.Unmarshal(item, &val) == nil {
+				choice = Choice{Value: val}
+			} else {
+				err = json.Unmarshal(item, &choice)
+			}
+		}
+
+		if err == nil {
+			cl.Options = append(cl.Options, choice)
+		}
+	}
+
+	return err
+}
+
+// MarshalJSON writes the template string or the choices, using plain strings for choices that
+// only have a value.
+func (cl ChoiceList) MarshalJSON() ([]byte, error) {
+	if !skelputil.IsBlank(cl.Template) {
+		return json.Marshal(cl.Template)
+	}
+
+	items := []interface{}{}
+	for _, c := range cl.Options {
+		if skelputil.IsBlank(c.Label) && skelputil.IsBlank(c.Help) {
+			items = append(items, c.Value)
+		} else {
+			items = append(items, c)
+		}
+	}
+
+	return json.Marshal(items)
 }
 
 // MultiValue allows the user to enter multiple values.
@@ -135,56 +443,43 @@ func (td *SkelplateDescriptor) UnmarshalJSON(data []byte) error {
 
 	if err == nil {
 		for k, v := range stuff {
+			if err != nil {
+				break
+			}
+
 			switch k {
 			case "author":
 				td.TemplateAuthor = v.(string)
 			case "repository":
 				td.TemplateRepo = v.(string)
+			case "description":
+				td.TemplateDesc = v.(string)
 			case "created":
 				td.TemplateCreated, _ = time.Parse(time.RFC3339Nano, v.(string))
 			case "modified":
 				td.TemplateModified, _ = time.Parse(time.RFC3339Nano, v.(string))
 			case "variables":
-				varSlice := []TemplateVariable{}
-				vars := v.([]interface{})
-				for _, vv := range vars {
-					//it's an object
-					if vvmap, ok := vv.(map[string]interface{}); ok {
-						var jsbytes []byte
-						jsbytes, err = json.Marshal(vv)
-
-						if err == nil {
-							switch typeOfVar(vvmap) {
-							case typeSelect:
-								var typedVar Selection
-								err = json.Unmarshal(jsbytes, &typedVar)
-								if err == nil {
-									varSlice = append(varSlice, &typedVar)
-								}
-							case typeMultiVal:
-								var typedVar MultiValue
-								err = json.Unmarshal(jsbytes, &typedVar)
-								if err == nil {
-									varSlice = append(varSlice, &typedVar)
-								}
-							case typeComplex:
-								var typedVar ComplexVar
-								err = json.Unmarshal(jsbytes, &typedVar)
-								if err == nil {
-									varSlice = append(varSlice, &typedVar)
-								}
-							case typeSimple:
-								var typedVar SimpleVar
-								err = json.Unmarshal(jsbytes, &typedVar)
-								if err == nil {
-									varSlice = append(varSlice, &typedVar)
-								}
-							}
-						}
+				if vars, ok := v.([]interface{}); ok {
+					td.TemplateVariables, err = unmarshalVariables(vars)
+				}
+			case "extends":
+				td.Extends = v.(string)
+			case "strict":
+				td.Strict, _ = v.(bool)
+			case "includes":
+				var jsbytes []byte
+				jsbytes, err = json.Marshal(v)
+
+				if err == nil {
+					err = json.Unmarshal(jsbytes, &td.Includes)
+				}
+			case "sections":
+				var jsbytes []byte
+				jsbytes, err = json.Marshal(v)
 
-					}
+				if err == nil {
+					err = json.Unmarshal(jsbytes, &td.Sections)
 				}
-				td.TemplateVariables = varSlice
 			}
 		}
 	}
@@ -192,7 +487,95 @@ func (td *SkelplateDescriptor) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// UnmarshalJSON creates an object variable, including its nested variables, from a JSON structure
+func (ov *ObjectVar) UnmarshalJSON(data []byte) error {
+	type objectVarAlias ObjectVar
+
+	aux := struct {
+		*objectVarAlias
+		Variables []interface{} `json:"variables"`
+	}{
+		objectVarAlias: (*objectVarAlias)(ov),
+	}
+
+	err := json.Unmarshal(data, &aux)
+
+	if err == nil {
+		ov.Variables, err = unmarshalVariables(aux.Variables)
+	}
+
+	return err
+}
+
+func unmarshalVariables(vars []interface{}) ([]TemplateVariable, error) {
+	var err error
+
+	varSlice := []TemplateVariable{}
+	for _, vv := range vars {
+		//it's an object
+		if vvmap, ok := vv.(map[string]interface{}); ok {
+			var jsbytes []byte
+			jsbytes, err = json.Marshal(vv)
+
+			if err == nil {
+				switch typeOfVar(vvmap) {
+				case typeSelect:
+					var typedVar Selection
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				case typeMultiVal:
+					var typedVar MultiValue
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				case typeComplex:
+					var typedVar ComplexVar
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				case typeComputed:
+					var typedVar Computed
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				case typeObject:
+					var typedVar ObjectVar
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				case typeSimple:
+					var typedVar SimpleVar
+					err = json.Unmarshal(jsbytes, &typedVar)
+					if err == nil {
+						varSlice = append(varSlice, &typedVar)
+					}
+				}
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return varSlice, err
+}
+
 func typeOfVar(varmap map[string]interface{}) string {
+	if computed, _ := varmap["computed"].(bool); computed {
+		return typeComputed
+	}
+
+	if _, ok := varmap["variables"]; ok {
+		return typeObject
+	}
+
 	if _, ok := varmap["choices"]; ok {
 		return typeSelect
 	}
@@ -201,7 +584,7 @@ func typeOfVar(varmap map[string]interface{}) string {
 		return typeMultiVal
 	}
 
-	rkeys := []string{"min", "max", "password", "prompt", "required"}
+	rkeys := []string{"min", "max", "password", "prompt", "help", "docsUrl", "required", "layout", "exists", "isDir", "relativeToOutput", "multiline"}
 	for _, k := range rkeys {
 		if _, ok := varmap[k]; ok {
 			return typeComplex
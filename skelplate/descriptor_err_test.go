@@ -54,8 +54,184 @@ var tmplErrTests = []struct {
 				"variables":[{"name":"beer", "default":""}]
 			}`,
 		map[string]interface{}{"beer": float64(1)},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"", "required":true}]
+			}`,
+		map[string]interface{}{"beer": "  "},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"ipa", "min":3, "max":5}]
+			}`,
+		map[string]interface{}{"beer": "hefeweizen"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"abv", "default":5, "min":3, "max":12}]
+			}`,
+		map[string]interface{}{"abv": float64(40)},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"kolsch", "choices":["pale","kolsch","stout"]}]
+			}`,
+		map[string]interface{}{"beer": "lager"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":["kolsch"], "mutlichoice":true, "choices":["pale","kolsch","stout"]}]
+			}`,
+		map[string]interface{}{"beer": []interface{}{"pale", "lager"}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":["ipa"], "required":true, "mutlival":true}]
+			}`,
+		map[string]interface{}{"beer": []interface{}{}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"port", "type":"int", "default":8080}]
+			}`,
+		map[string]interface{}{"port": float64(80.5)},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"port", "type":"int", "default":8080}]
+			}`,
+		map[string]interface{}{"port": "80"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"brewed", "type":"date", "default":""}]
+			}`,
+		map[string]interface{}{"brewed": "June 7th"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"cellar", "type":"path", "exists":true, "default":""}]
+			}`,
+		map[string]interface{}{"cellar": "/does/not/exist"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"db", "default":"mysql", "choices":[{"label":"MySQL 8", "value":"mysql"}]}]
+			}`,
+		map[string]interface{}{"db": "MySQL 8"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":["pale"], "mutlichoice":true, "minPicks":2, "choices":["pale","kolsch","stout"]}]
+			}`,
+		map[string]interface{}{"beer": []interface{}{"pale"}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":["pale"], "mutlichoice":true, "maxPicks":1, "choices":["pale","kolsch","stout"]}]
+			}`,
+		map[string]interface{}{"beer": []interface{}{"pale", "stout"}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"", "choices":"{{.nope"}]
+			}`,
+		nil,
+		"unable to parse variable choices template:",
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"packageName", "computed":true, "value":"{{.TemplateAuthor}}pkg"}]
+			}`,
+		map[string]interface{}{"packageName": "mine"},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"port", "computed":true, "type":"int", "value":"eighty"}]
+			}`,
+		nil,
+		"unable to convert computed value:",
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"owner", "variables":[{"name":"first", "default":""}]}]
+			}`,
+		map[string]interface{}{"owner": "jane"},
 		"invalid type for provided data entry",
 	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"owner", "repeated":true, "variables":[{"name":"first", "default":"", "min":3}]}]
+			}`,
+		map[string]interface{}{"owner": []interface{}{map[string]interface{}{"first": "jo"}}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"beers", "default":["ipa"], "min":3, "max":5}]
+			}`,
+		map[string]interface{}{"beers": []interface{}{"ipa", "stout", "porter ale"}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"brewed", "type":"date", "default":["2017-01-02"]}]
+			}`,
+		map[string]interface{}{"brewed": []interface{}{"last tuesday"}},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"cheese", "default":"", "required":true, "choices":["gouda","brie"]}]
+			}`,
+		map[string]interface{}{"cheese": ""},
+		ErrInvalidProvidedData,
+	},
+	{
+		`{
+				"author": "brainicorn",
+				"variables":[{"name":"cheeses", "default":"", "required":true, "mutlichoice":true, "choices":["gouda","brie"]}]
+			}`,
+		map[string]interface{}{"cheeses": []interface{}{}},
+		ErrInvalidProvidedData,
+	},
 }
 
 func TestTemplateParseErrors(t *testing.T) {
@@ -83,6 +259,103 @@ func TestTemplateParseErrors(t *testing.T) {
 	}
 }
 
+func TestProvidedDataViolationsReportedTogether(t *testing.T) {
+	descJSON := `{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"ipa", "min":3}
+					,{"name":"food", "default":"", "required":true}
+					,{"name":"cheese", "default":"gouda", "choices":["gouda","brie"]}
+				]
+			}`
+
+	dp := NewDataProvider(map[string]interface{}{"beer": "a", "cheese": "cheddar"})
+
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(descJSON), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s\n%s", descJSON, err)
+	}
+
+	_, err = dp.gatherData(descriptor)
+
+	if err == nil {
+		t.Fatalf("expected error but was nil")
+	}
+
+	for _, want := range []string{"beer:", "cheese:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing violation for (%s) in (%s)", want, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "food:") {
+		t.Errorf("unprovided variable should not be reported: %s", err)
+	}
+}
+
+func TestProvidedDataViolationsReportedBeforePrompting(t *testing.T) {
+	descJSON := `{
+				"author": "brainicorn",
+				"variables":[{"name":"food", "default":"pizza"}
+					,{"name":"cheese", "default":"gouda", "choices":["gouda","brie"]}
+				]
+			}`
+
+	dp := NewDataProvider(map[string]interface{}{"cheese": "cheddar"})
+	dp.beforePrompt = func() {
+		t.Fatalf("provided data should be checked before prompting")
+	}
+
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(descJSON), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s\n%s", descJSON, err)
+	}
+
+	_, err = dp.gatherData(descriptor)
+
+	if err == nil || !strings.HasPrefix(err.Error(), ErrInvalidProvidedData) || !strings.Contains(err.Error(), "cheese:") {
+		t.Errorf("wrong error: have (%v) want a violation for cheese", err)
+	}
+}
+
+func TestProvidedDataWrongTypeReportedWithViolations(t *testing.T) {
+	descJSON := `{
+				"author": "brainicorn",
+				"variables":[{"name":"beer", "default":"ipa"}
+					,{"name":"food", "default":"", "min":3}
+					,{"name":"beers", "default":["ipa"], "max":5}
+				]
+			}`
+
+	dp := NewDataProvider(map[string]interface{}{"beer": float64(1), "food": "a", "beers": []interface{}{"ipa", "porter ale"}})
+
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(descJSON), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s\n%s", descJSON, err)
+	}
+
+	_, err = dp.gatherData(descriptor)
+
+	if err == nil {
+		t.Fatalf("expected error but was nil")
+	}
+
+	for _, want := range []string{"invalid type for provided data entry 'beer'", "food:", "beers[1]:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing violation for (%s) in (%s)", want, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "beers[0]:") {
+		t.Errorf("valid list value should not be reported: %s", err)
+	}
+}
+
 type fakeInterruptingUser struct {
 	in         *os.File
 	keystrokes []string
@@ -7,9 +7,12 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/AlecAivazis/survey/core"
+	"github.com/brainicorn/skelp/prompter"
 )
 
 var tmplTests = []struct {
@@ -151,6 +154,158 @@ var tmplTests = []struct {
 		[]string{" \x0e \x0e "},
 		map[string]interface{}{"rounds": []interface{}{float64(1), float64(5)}},
 	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"port","type":"int","default":8080}]
+							}`,
+		[]string{"9090"},
+		map[string]interface{}{"port": 9090},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"ratio","type":"float","default":1}]
+							}`,
+		[]string{""},
+		map[string]interface{}{"ratio": float64(1)},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"brewed","type":"date","layout":"01/02/2006","default":"06/07/2017"}]
+							}`,
+		[]string{""},
+		map[string]interface{}{"brewed": time.Date(2017, 6, 7, 0, 0, 0, 0, time.UTC)},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"cellar","type":"path","exists":true,"isDir":true,"default":"."}]
+							}`,
+		[]string{"/does/not/exist", ""},
+		map[string]interface{}{"cellar": "."},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{
+								"name":"rounds",
+								"type":"int",
+								"default":[2],
+								"mutlichoice":true,
+								"choices":["1","2","5","7"]
+								}]
+							}`,
+		[]string{" \x0e \x0e "},
+		map[string]interface{}{"rounds": []interface{}{1, 5}},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{
+								"name":"db",
+								"default":"mysql",
+								"choices":[
+									{"label":"PostgreSQL 15", "value":"postgres", "help":"the elephant"},
+									{"label":"MySQL 8", "value":"mysql"},
+									"sqlite"
+								]
+								}]
+							}`,
+		[]string{"\x0e"},
+		map[string]interface{}{"db": "sqlite"},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"beers","default":["ipa","stout"],"mutlival":true}
+								,{
+								"name":"favorite",
+								"default":"{{index .beers 0}}",
+								"choices":"{{join \",\" .beers}}"
+								}]
+							}`,
+		[]string{"ale", "y", "porter", "n", "\x0e"},
+		map[string]interface{}{"beers": []interface{}{"ale", "porter"}, "favorite": "porter"},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"brewery","default":"acme"}
+								,{
+								"name":"repo",
+								"default":"{{.brewery}}/beers",
+								"choices":[{"label":"{{.brewery}} beers", "value":"{{.brewery}}/beers"}]
+								}]
+							}`,
+		[]string{"", ""},
+		map[string]interface{}{"brewery": "acme", "repo": "acme/beers"},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"projectName","default":"MyBeer"}
+								,{"name":"packageName","computed":true,"value":"{{.projectName | lower}}"}
+								,{"name":"abv","computed":true,"type":"float","value":"{{add 5 1}}.5"}
+								]
+							}`,
+		[]string{""},
+		map[string]interface{}{"projectName": "MyBeer", "packageName": "mybeer", "abv": float64(6.5)},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"abv","computed":true,"type":"float","value":" {{add 5 1}}.5\n"}]
+							}`,
+		[]string{""},
+		map[string]interface{}{"abv": float64(6.5)},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{"name":"brewery","default":"acme"}
+								,{
+								"name":"owner",
+								"variables":[{"name":"first","default":"joe"}
+									,{"name":"email","default":"{{.first}}@{{.brewery}}.com"}
+								]
+								}]
+							}`,
+		[]string{"", "jane", ""},
+		map[string]interface{}{"brewery": "acme", "owner": map[string]interface{}{"first": "jane", "email": "jane@acme.com"}},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{
+								"name":"taps",
+								"repeated":true,
+								"addPrompt":"add another tap?",
+								"variables":[{"name":"style","default":"ipa"},{"name":"abv","default":5}]
+								}]
+							}`,
+		[]string{"ale", "4.5", "y", "", "", "n"},
+		map[string]interface{}{"taps": []interface{}{
+			map[string]interface{}{"style": "ale", "abv": float64(4.5)},
+			map[string]interface{}{"style": "ipa", "abv": float64(5)},
+		}},
+	},
+	{
+		`{
+							  "author": "brainicorn",
+							  "variables":[{
+								"name":"taps",
+								"repeated":true,
+								"key":"style",
+								"variables":[{"name":"style","default":"ipa"},{"name":"abv","default":5}]
+								}]
+							}`,
+		[]string{"", "", "n"},
+		map[string]interface{}{"taps": map[string]interface{}{
+			"ipa": map[string]interface{}{"style": "ipa", "abv": float64(5)},
+		}},
+	},
 }
 
 func TestGatherData(t *testing.T) {
@@ -202,3 +357,137 @@ func TestGatherData(t *testing.T) {
 
 	}
 }
+
+func TestGatherDataScripted(t *testing.T) {
+	core.DisableColor = true
+
+	for _, tt := range tmplTests {
+		var descriptor SkelplateDescriptor
+		err := json.Unmarshal([]byte(tt.tmpl), &descriptor)
+
+		if err != nil {
+			t.Errorf("error parsing template: %s\n%s", tt.tmpl, err)
+		}
+
+		term := prompter.NewScriptedTerminal(tt.input...)
+		dp := NewDataProvider(nil)
+		dp.SetTerminal(term)
+
+		valmap, err := dp.gatherData(descriptor)
+
+		if err != nil {
+			t.Errorf("error gathering data: %s\n%s", tt.tmpl, err)
+		}
+
+		for k := range builtinData(descriptor) {
+			delete(valmap, k)
+		}
+
+		if !reflect.DeepEqual(tt.expected, valmap) {
+			t.Errorf("template parse error:\n  expected:\n  %+v\n  actual:\n  %+v", tt.expected, valmap)
+		}
+	}
+}
+
+var tmplHelpTests = []struct {
+	variable string
+	help     string
+}{
+	{`{"name":"beer","default":"ipa","help":"the style you brew most"}`, "the style you brew most"},
+	{`{"name":"beer","default":"ipa","docsUrl":"https://beer.example/styles"}`, "see https://beer.example/styles"},
+	{`{"name":"beer","default":"ipa","help":"the style you brew most","docsUrl":"https://beer.example/styles"}`, "the style you brew most (see https://beer.example/styles)"},
+	{`{"name":"beer","default":"ipa","help":"the style you brew most","choices":["ipa","stout"]}`, "the style you brew most"},
+}
+
+func TestPromptHelp(t *testing.T) {
+	core.DisableColor = true
+
+	for _, tt := range tmplHelpTests {
+		var descriptor SkelplateDescriptor
+		err := json.Unmarshal([]byte(`{"author":"brainicorn","variables":[`+tt.variable+`]}`), &descriptor)
+
+		if err != nil {
+			t.Fatalf("error parsing variable: %s\n%s", tt.variable, err)
+		}
+
+		term := prompter.NewScriptedTerminal()
+		term.Type("?")
+		if _, isSelect := descriptor.TemplateVariables[0].(*Selection); !isSelect {
+			term.Type("\n")
+		}
+		term.Answer("")
+
+		dp := NewDataProvider(nil)
+		dp.SetTerminal(term)
+
+		if _, err = dp.gatherData(descriptor); err != nil {
+			t.Errorf("error gathering data: %s\n%s", tt.variable, err)
+		}
+
+		if !strings.Contains(term.Output(), tt.help) {
+			t.Errorf("help was not displayed, want (%s) in:\n%s", tt.help, term.Output())
+		}
+	}
+}
+
+var objectDataTests = []struct {
+	tmpl     string
+	provided map[string]interface{}
+	expected interface{}
+}{
+	{
+		`{
+			"author": "brainicorn",
+			"variables":[{"name":"owner","variables":[{"name":"first","default":"joe"},{"name":"email","default":"{{.first}}@acme.com"}]}]
+		}`,
+		map[string]interface{}{"owner": map[string]interface{}{"first": "jane", "email": "{{.first}}@beer.com"}},
+		map[string]interface{}{"first": "jane", "email": "jane@beer.com"},
+	},
+	{
+		`{
+			"author": "brainicorn",
+			"variables":[{"name":"owner","repeated":true,"variables":[{"name":"first","default":"joe"},{"name":"port","type":"int","default":80}]}]
+		}`,
+		map[string]interface{}{"owner": []interface{}{
+			map[string]interface{}{"first": "jane", "port": float64(8080)},
+			map[string]interface{}{"first": "bob", "port": float64(80)},
+		}},
+		[]interface{}{
+			map[string]interface{}{"first": "jane", "port": 8080},
+			map[string]interface{}{"first": "bob", "port": 80},
+		},
+	},
+	{
+		`{
+			"author": "brainicorn",
+			"variables":[{"name":"owner","repeated":true,"key":"first","variables":[{"name":"first","default":"joe"},{"name":"last","default":"smith"}]}]
+		}`,
+		map[string]interface{}{"owner": map[string]interface{}{
+			"jane": map[string]interface{}{"last": "doe"},
+		}},
+		map[string]interface{}{"jane": map[string]interface{}{"first": "jane", "last": "doe"}},
+	},
+}
+
+func TestObjectProvidedData(t *testing.T) {
+	for _, tt := range objectDataTests {
+		dp := NewDataProvider(tt.provided)
+
+		var descriptor SkelplateDescriptor
+		err := json.Unmarshal([]byte(tt.tmpl), &descriptor)
+
+		if err != nil {
+			t.Fatalf("error parsing descriptor: %s\n%s", tt.tmpl, err)
+		}
+
+		valmap, err := dp.gatherData(descriptor)
+
+		if err != nil {
+			t.Fatalf("error gathering data: %s\n%s", tt.tmpl, err)
+		}
+
+		if !reflect.DeepEqual(tt.expected, valmap["owner"]) {
+			t.Errorf("wrong object:\n  expected:\n  %+v\n  actual:\n  %+v", tt.expected, valmap["owner"])
+		}
+	}
+}
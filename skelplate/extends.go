@@ -0,0 +1,196 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/brainicorn/skelp/provider"
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+// ParentProviderFunc returns the local path of the template extended by the template at templateRoot.
+// The parent is remembered so that DataProviderFunc can merge the descriptors of the templates.
+func (sdp *SkelplateDataProvider) ParentProviderFunc(templateRoot string, resolver provider.TemplateResolver) (string, error) {
+	var err error
+	var extends string
+	var parentRoot string
+
+	extends, err = readExtends(templateRoot)
+
+	if err == nil && !skelputil.IsBlank(extends) {
+		parentRoot, err = resolver(extends)
+	}
+
+	if err == nil && !skelputil.IsBlank(parentRoot) {
+		parentRoot, err = filepath.Abs(parentRoot)
+	}
+
+	if err == nil {
+		sdp.parents[templateRoot] = parentRoot
+	}
+
+	return parentRoot, err
+}
+
+// readExtends returns the id of the template extended by the template at templateRoot.
+// A missing descriptor extends nothing, it gets reported when the data is gathered.
+func readExtends(templateRoot string) (string, error) {
+	var err error
+	var descriptorBytes []byte
+	var descriptor struct {
+		Extends string `json:"extends"`
+	}
+
+	jsonPath := filepath.Join(templateRoot, skelpFilename)
+
+	if !skelputil.PathExists(jsonPath) {
+		return "", nil
+	}
+
+	descriptorBytes, err = ioutil.ReadFile(jsonPath)
+
+	if err == nil {
+		err = json.Unmarshal(descriptorBytes, &descriptor)
+	}
+
+	return descriptor.Extends, err
+}
+
+// descriptorBytes reads the descriptor at templateRoot merged with the descriptors it extends.
+func (sdp *SkelplateDataProvider) descriptorBytes(templateRoot string) ([]byte, error) {
+	var err error
+	var descriptorBytes []byte
+	var parentBytes []byte
+
+	jsonPath := filepath.Join(templateRoot, skelpFilename)
+	if !skelputil.PathExists(jsonPath) {
+		err = fmt.Errorf(ErrSkelpFileNotFound, jsonPath)
+	}
+
+	if err == nil {
+		descriptorBytes, err = ioutil.ReadFile(jsonPath)
+	}
+
+	parentRoot := sdp.parents[templateRoot]
+
+	if err == nil && !skelputil.IsBlank(parentRoot) {
+		parentBytes, err = sdp.descriptorBytes(parentRoot)
+
+		if err == nil {
+			descriptorBytes, err = mergeDescriptors(parentRoot, parentBytes, descriptorBytes)
+		}
+	}
+
+	return descriptorBytes, err
+}
+
+// mergeDescriptors lays a child descriptor over its parent.
+// Child fields replace the parent's, variables are merged by name so a child can override just the
+// default or prompt of a parent variable, sections are merged by title and includes from both are kept.
+func mergeDescriptors(parentRoot string, parentBytes, childBytes []byte) ([]byte, error) {
+	var err error
+	var parent map[string]interface{}
+	var child map[string]interface{}
+
+	err = json.Unmarshal(parentBytes, &parent)
+
+	if err == nil {
+		err = json.Unmarshal(childBytes, &child)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// relative includes in the parent are relative to the parent, not the child
+	parentIncludes, _ := parent["includes"].([]interface{})
+	for _, inc := range parentIncludes {
+		if incmap, ok := inc.(map[string]interface{}); ok {
+			if tid, ok := incmap["template"].(string); ok && isRelativeTemplatePath(tid) {
+				incmap["template"] = filepath.Join(parentRoot, tid)
+			}
+		}
+	}
+
+	for k, v := range child {
+		switch k {
+		case "variables":
+			parentVars, _ := parent[k].([]interface{})
+			childVars, _ := v.([]interface{})
+			parent[k] = mergeEntries(parentVars, childVars, "name")
+		case "sections":
+			parentSections, _ := parent[k].([]interface{})
+			childSections, _ := v.([]interface{})
+			parent[k] = mergeEntries(parentSections, childSections, "title")
+		case "includes":
+			childIncludes, _ := v.([]interface{})
+			parent[k] = append(parentIncludes, childIncludes...)
+		default:
+			parent[k] = v
+		}
+	}
+
+	delete(parent, "extends")
+
+	return json.Marshal(parent)
+}
+
+// isRelativeTemplatePath reports whether a template id is a path relative to the template that
+// refers to it. Ids with a scheme or user are urls, a single name is an alias and a first element
+// with a dot is a repository host, e.g. github.com/brainicorn/ci.
+func isRelativeTemplatePath(templateID string) bool {
+	if filepath.IsAbs(templateID) || strings.ContainsAny(templateID, ":@") {
+		return false
+	}
+
+	if strings.HasPrefix(templateID, ".") {
+		return true
+	}
+
+	elems := strings.Split(filepath.ToSlash(templateID), "/")
+
+	return len(elems) > 1 && !strings.Contains(elems[0], ".")
+}
+
+// mergeEntries lays child entries over the parent entries with the same value for key.
+// Entries without a match in the parent are appended.
+func mergeEntries(parentVars, childVars []interface{}, key string) []interface{} {
+	merged := []interface{}{}
+	positions := make(map[string]int)
+
+	for _, pv := range parentVars {
+		if pvmap, ok := pv.(map[string]interface{}); ok {
+			if name, ok := pvmap[key].(string); ok {
+				positions[name] = len(merged)
+			}
+		}
+
+		merged = append(merged, pv)
+	}
+
+	for _, cv := range childVars {
+		cvmap, ok := cv.(map[string]interface{})
+		if !ok {
+			merged = append(merged, cv)
+			continue
+		}
+
+		name, _ := cvmap[key].(string)
+		pos, overrides := positions[name]
+
+		if !overrides {
+			merged = append(merged, cv)
+			continue
+		}
+
+		pvmap := merged[pos].(map[string]interface{})
+		for k, v := range cvmap {
+			pvmap[k] = v
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,78 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeDescriptors(t *testing.T) {
+	parentJSON := `{
+				"author": "brainicorn",
+				"repository": "https://github.com/brainicorn/base",
+				"variables":[{"name":"beer", "default":"ipa", "prompt":"what beer?"}
+					,{"name":"food", "default":"pizza"}
+				],
+				"includes":[{"template":"../license"}, {"template":"sub/readme"}, {"template":"github.com/brainicorn/ci"}, {"template":"myalias"}],
+				"sections":[{"title":"Drinks", "description":"what to drink", "variables":["beer"]}]
+			}`
+
+	childJSON := `{
+				"author": "someoneelse",
+				"extends": "../base",
+				"variables":[{"name":"beer", "default":"stout"}
+					,{"name":"cheese", "default":"gouda"}
+				],
+				"includes":[{"template":"https://github.com/brainicorn/ci"}],
+				"sections":[{"title":"Drinks", "variables":["beer", "cheese"]}, {"title":"Food", "variables":["food"]}]
+			}`
+
+	merged, err := mergeDescriptors("/skelplates/base", []byte(parentJSON), []byte(childJSON))
+
+	if err != nil {
+		t.Fatalf("error merging descriptors: %s", err)
+	}
+
+	var descriptor SkelplateDescriptor
+	err = json.Unmarshal(merged, &descriptor)
+
+	if err != nil {
+		t.Fatalf("error parsing merged descriptor: %s\n%s", merged, err)
+	}
+
+	if descriptor.TemplateAuthor != "someoneelse" || descriptor.TemplateRepo != "https://github.com/brainicorn/base" {
+		t.Errorf("wrong fields: have (%s, %s)", descriptor.TemplateAuthor, descriptor.TemplateRepo)
+	}
+
+	if descriptor.Extends != "" {
+		t.Errorf("extends should not be merged: have (%s)", descriptor.Extends)
+	}
+
+	names := []string{}
+	for _, v := range descriptor.TemplateVariables {
+		names = append(names, v.Name())
+	}
+
+	if len(names) != 3 || names[0] != "beer" || names[1] != "food" || names[2] != "cheese" {
+		t.Fatalf("wrong variables: have (%v), want (%v)", names, []string{"beer", "food", "cheese"})
+	}
+
+	beer := descriptor.TemplateVariables[0].(*ComplexVar)
+	if beer.Default() != "stout" || beer.Prompt != "what beer?" {
+		t.Errorf("wrong override: have (%s, %s), want (%s, %s)", beer.Default(), beer.Prompt, "stout", "what beer?")
+	}
+
+	includes := []string{}
+	for _, inc := range descriptor.Includes {
+		includes = append(includes, inc.TemplateID)
+	}
+
+	wantIncludes := []string{"/skelplates/license", "/skelplates/base/sub/readme", "github.com/brainicorn/ci", "myalias", "https://github.com/brainicorn/ci"}
+	if !reflect.DeepEqual(includes, wantIncludes) {
+		t.Errorf("wrong includes: have (%v), want (%v)", includes, wantIncludes)
+	}
+
+	if len(descriptor.Sections) != 2 || descriptor.Sections[0].Description != "what to drink" || len(descriptor.Sections[0].Variables) != 2 {
+		t.Errorf("wrong sections: have (%+v)", descriptor.Sections)
+	}
+}
@@ -0,0 +1,326 @@
+package skelplate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/brainicorn/skelp/skelputil"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+)
+
+const (
+	ErrExtractInvalidVar   = "%s is not a valid variable name"
+	ErrExtractBlankLiteral = "the text to replace with %s can not be blank"
+	ErrExtractDuplicateVar = "variable %s was given more than once"
+	ErrExtractSameDir      = "the skelplate can not be extracted into the project itself: %s"
+
+	gitDirname       = ".git"
+	gitignoreFile    = ".gitignore"
+	binarySniffBytes = 512
+
+	// escapedDelim is how an existing {{ in the project is written so the executor renders it as-is.
+	// a raw string is used because quotes are not allowed in windows file names.
+	escapedDelim = "{{`{{`}}"
+)
+
+// caseVariants are the template expressions tried against each literal when case variants are asked for.
+// A variant is only used when rendering it with the literal gives text that differs from the literal.
+// They use skelp's case functions, which split words on separators as well as changes of case.
+var caseVariants = []string{
+	"pascalCase %s",
+	"camelCase %s",
+	"snakeCase %s",
+	"kebabCase %s",
+	"screamingSnakeCase %s",
+	"upper %s",
+	"lower %s",
+}
+
+// ExtractVar is a variable to create from a literal found in the project.
+type ExtractVar struct {
+	Name    string
+	Literal string
+}
+
+// ExtractOptions control how a project is turned into a skelplate.
+type ExtractOptions struct {
+	Vars []ExtractVar
+
+	// CaseVariants also replaces the camel, pascal, snake, kebab, upper and lower case forms of each literal.
+	// Case variants work best when the literal is given with separators, e.g. my-service.
+	CaseVariants bool
+
+	Author      string
+	Description string
+}
+
+type extractReplacement struct {
+	literal string
+	expr    string
+}
+
+// Extract copies the project at projectDir into a new skelplate at skelplateDir.
+// Every occurrence of a variable's literal in file contents and paths is replaced with a template
+// expression for the variable, existing template delimiters are escaped, files matched by the
+// project's .gitignore files are left out and a skelp.json declaring the variables is written.
+func Extract(projectDir, skelplateDir string, opts ExtractOptions) error {
+	var err error
+	var absProject, absSkelplate string
+	var replacements []extractReplacement
+	var ignores []gitignore.Pattern
+
+	absProject, err = filepath.Abs(projectDir)
+
+	if err == nil {
+		absSkelplate, err = filepath.Abs(skelplateDir)
+	}
+
+	if err == nil && absProject == absSkelplate {
+		err = fmt.Errorf(ErrExtractSameDir, absProject)
+	}
+
+	if err == nil {
+		replacements, err = extractReplacements(opts)
+	}
+
+	if err == nil {
+		ignores, err = readGitignore(absProject, "")
+	}
+
+	if err == nil {
+		err = writeDescriptor(absSkelplate, extractDescriptor(opts))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	tmplRoot := filepath.Join(absSkelplate, skelpTemplatesDirname)
+
+	return filepath.Walk(absProject, func(curPath string, fi os.FileInfo, werr error) error {
+		if werr != nil {
+			return werr
+		}
+
+		if curPath == absProject {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(absProject, curPath)
+		slashRel := filepath.ToSlash(rel)
+
+		ignored := gitignore.NewMatcher(ignores).Match(strings.Split(slashRel, "/"), fi.IsDir())
+
+		if curPath == absSkelplate || fi.Name() == gitDirname || ignored {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		target := filepath.Join(tmplRoot, filepath.FromSlash(templatize(slashRel, replacements)))
+
+		if fi.IsDir() {
+			// filepath.Walk visits a directory before its contents so its patterns are in place for them
+			dirIgnores, err := readGitignore(absProject, rel)
+
+			if err == nil {
+				ignores = append(ignores, dirIgnores...)
+				err = os.MkdirAll(target, os.ModePerm)
+			}
+
+			return err
+		}
+
+		return extractFile(curPath, target, fi.Mode(), replacements)
+	})
+}
+
+func extractFile(src, target string, mode os.FileMode, replacements []extractReplacement) error {
+	var err error
+	var content []byte
+
+	content, err = ioutil.ReadFile(src)
+
+	if err == nil {
+		// binary files only get their delimiters escaped so they survive the executor untouched
+		if isBinary(content) {
+			content = []byte(templatize(string(content), nil))
+		} else {
+			content = []byte(templatize(string(content), replacements))
+		}
+
+		err = ioutil.WriteFile(target, content, mode)
+	}
+
+	return err
+}
+
+func extractDescriptor(opts ExtractOptions) SkelplateDescriptor {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	descriptor := SkelplateDescriptor{
+		TemplateAuthor:    opts.Author,
+		TemplateDesc:      opts.Description,
+		TemplateCreated:   now,
+		TemplateModified:  now,
+		TemplateVariables: []TemplateVariable{},
+	}
+
+	for _, v := range opts.Vars {
+		descriptor.TemplateVariables = append(descriptor.TemplateVariables, &SimpleVar{Varname: v.Name, DefaultVal: v.Literal})
+	}
+
+	return descriptor
+}
+
+// extractReplacements builds the literal to expression mapping, longest literals first so that a
+// literal containing another literal is replaced as a whole.
+func extractReplacements(opts ExtractOptions) ([]extractReplacement, error) {
+	var err error
+	var variant string
+
+	replacements := []extractReplacement{}
+	seenNames := make(map[string]bool)
+	seenLiterals := make(map[string]bool)
+	fmap := skelputil.FunctionMap()
+
+	add := func(literal, expr string) {
+		if !skelputil.IsBlank(literal) && !seenLiterals[literal] {
+			seenLiterals[literal] = true
+			replacements = append(replacements, extractReplacement{literal: literal, expr: expr})
+		}
+	}
+
+	for _, v := range opts.Vars {
+		switch {
+		case !varNameRegExp.MatchString(v.Name):
+			return nil, fmt.Errorf(ErrExtractInvalidVar, v.Name)
+		case skelputil.IsBlank(v.Literal):
+			return nil, fmt.Errorf(ErrExtractBlankLiteral, v.Name)
+		case seenNames[v.Name]:
+			return nil, fmt.Errorf(ErrExtractDuplicateVar, v.Name)
+		}
+
+		seenNames[v.Name] = true
+		add(v.Literal, "{{."+v.Name+"}}")
+	}
+
+	if opts.CaseVariants {
+		for _, v := range opts.Vars {
+			for _, cv := range caseVariants {
+				expr := fmt.Sprintf(cv, "."+v.Name)
+				variant, err = renderVariant(expr, fmap, v.Name, v.Literal)
+
+				if err != nil {
+					return nil, err
+				}
+
+				add(variant, "{{"+expr+"}}")
+			}
+		}
+	}
+
+	sort.SliceStable(replacements, func(i, j int) bool {
+		return len(replacements[i].literal) > len(replacements[j].literal)
+	})
+
+	return replacements, nil
+}
+
+func renderVariant(expr string, fmap map[string]interface{}, name, literal string) (string, error) {
+	var err error
+	var tmpl *template.Template
+	var b bytes.Buffer
+
+	tmpl, err = template.New("variant").Funcs(fmap).Parse("{{" + expr + "}}")
+
+	if err == nil {
+		err = tmpl.Execute(&b, map[string]interface{}{name: literal})
+	}
+
+	return b.String(), err
+}
+
+// templatize escapes existing template delimiters in text and replaces every literal with its expression.
+func templatize(text string, replacements []extractReplacement) string {
+	var b bytes.Buffer
+
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], "{{") {
+			b.WriteString(escapedDelim)
+			i += 2
+			continue
+		}
+
+		replaced := false
+		for _, r := range replacements {
+			if strings.HasPrefix(text[i:], r.literal) {
+				b.WriteString(r.expr)
+				i += len(r.literal)
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			b.WriteByte(text[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+func isBinary(content []byte) bool {
+	sniff := content
+	if len(sniff) > binarySniffBytes {
+		sniff = sniff[:binarySniffBytes]
+	}
+
+	return bytes.IndexByte(sniff, 0) > -1
+}
+
+// readGitignore reads the patterns in the .gitignore of the directory at rel within the project.
+// The patterns only apply to paths within that directory, the same as nested .gitignore files in git.
+func readGitignore(root, rel string) ([]gitignore.Pattern, error) {
+	var patterns []gitignore.Pattern
+	var domain []string
+
+	if rel != "" {
+		domain = strings.Split(filepath.ToSlash(rel), "/")
+	}
+
+	f, err := os.Open(filepath.Join(root, rel, gitignoreFile))
+	if os.IsNotExist(err) {
+		return patterns, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+
+	return patterns, scanner.Err()
+}
@@ -0,0 +1,296 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/brainicorn/skelp/executor"
+	"github.com/brainicorn/skelp/provider"
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+var extractProject = map[string]string{
+	".gitignore":         "build/\n*.tmp\n!keep.tmp\n",
+	"README.md":          "# MyService\n\nmy-service is built by acme.\n\nRun it with `MY_SERVICE_PORT=8080 ./my-service`.\nHelm values look like {{ .Values.port }} and are left alone.\n",
+	"my-service/main.go": "package my_service\n\n// MyService is the acme service.\ntype MyService struct{}\n",
+	"build/out.txt":      "generated\n",
+	"notes.tmp":          "scratch\n",
+	"keep.tmp":           "kept\n",
+}
+
+func writeExtractProject(t *testing.T, files map[string]string) string {
+	dir, _ := ioutil.TempDir("", "skelp-extract-project")
+
+	for rel, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		os.MkdirAll(filepath.Dir(target), os.ModePerm)
+
+		if err := ioutil.WriteFile(target, []byte(content), 0644); err != nil {
+			t.Fatalf("error writing project: %s", err)
+		}
+	}
+
+	return dir
+}
+
+func TestExtractRoundTrip(t *testing.T) {
+	projectDir := writeExtractProject(t, extractProject)
+	defer os.RemoveAll(projectDir)
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-extract-test")
+	defer os.RemoveAll(tmpDir)
+
+	skelplateDir := filepath.Join(tmpDir, "skelplate")
+	opts := ExtractOptions{
+		Vars:         []ExtractVar{{Name: "projectName", Literal: "my-service"}, {Name: "org", Literal: "acme"}},
+		CaseVariants: true,
+		Author:       "brainicorn",
+	}
+
+	if err := Extract(projectDir, skelplateDir, opts); err != nil {
+		t.Fatalf("error extracting project: %s", err)
+	}
+
+	tmplRoot := filepath.Join(skelplateDir, "templates")
+
+	for _, ignored := range []string{"build", "notes.tmp"} {
+		if skelputil.PathExists(filepath.Join(tmplRoot, ignored)) {
+			t.Errorf("ignored path %s should not have been extracted", ignored)
+		}
+	}
+
+	if !skelputil.PathExists(filepath.Join(tmplRoot, "{{.projectName}}", "main.go")) {
+		t.Errorf("path was not templated")
+	}
+
+	readme, _ := ioutil.ReadFile(filepath.Join(tmplRoot, "README.md"))
+	for _, want := range []string{"# {{pascalCase .projectName}}", "{{screamingSnakeCase .projectName}}_PORT", "{{.org}}", "{{`{{`}} .Values.port }}"} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("extracted readme is missing %s:\n%s", want, readme)
+		}
+	}
+
+	// the descriptor lints cleanly
+	problems, err := NewDataProvider(nil).Lint(skelplateDir, []string{tmplRoot}, nil)
+
+	if err != nil {
+		t.Fatalf("error linting extracted skelplate: %s", err)
+	}
+
+	if len(problems) > 0 {
+		t.Errorf("extracted skelplate has lint problems: %v", problems)
+	}
+
+	// applying the skelplate with the extracted defaults gives back the project
+	outDir := filepath.Join(tmpDir, "out")
+	data := map[string]interface{}{"projectName": "my-service", "org": "acme"}
+	exec := executor.New(skelputil.FunctionMap(), skelputil.TemplateOptions())
+
+	if err = exec.Execute(tmplRoot, outDir, data, provider.AlwaysOverwriteProvider); err != nil {
+		t.Fatalf("error applying extracted skelplate: %s", err)
+	}
+
+	for rel, content := range extractProject {
+		have, rerr := ioutil.ReadFile(filepath.Join(outDir, filepath.FromSlash(rel)))
+
+		switch {
+		case strings.HasPrefix(rel, "build/") || rel == "notes.tmp":
+			if rerr == nil {
+				t.Errorf("ignored file %s was generated", rel)
+			}
+		case rerr != nil:
+			t.Errorf("file %s was not generated: %s", rel, rerr)
+		case string(have) != content:
+			t.Errorf("file %s does not match:\nhave (%s)\nwant (%s)", rel, have, content)
+		}
+	}
+
+	// and other answers change every case variant
+	outDir = filepath.Join(tmpDir, "out-other")
+	data = map[string]interface{}{"projectName": "beer-tracker", "org": "brewery"}
+
+	if err = exec.Execute(tmplRoot, outDir, data, provider.AlwaysOverwriteProvider); err != nil {
+		t.Fatalf("error applying extracted skelplate: %s", err)
+	}
+
+	code, _ := ioutil.ReadFile(filepath.Join(outDir, "beer-tracker", "main.go"))
+	want := "package beer_tracker\n\n// BeerTracker is the brewery service.\ntype BeerTracker struct{}\n"
+
+	if string(code) != want {
+		t.Errorf("generated code does not match:\nhave (%s)\nwant (%s)", code, want)
+	}
+}
+
+var gitignoreTests = []struct {
+	name    string
+	files   map[string]string
+	ignored []string
+	kept    []string
+}{
+	{
+		"double asterisk",
+		map[string]string{
+			".gitignore":         "**/logs/*.log\n",
+			"logs/a.log":         "a\n",
+			"deep/x/logs/b.log":  "b\n",
+			"deep/x/logs/b.txt":  "b\n",
+			"deep/x/notlogs.log": "c\n",
+		},
+		[]string{"logs/a.log", "deep/x/logs/b.log"},
+		[]string{"deep/x/logs/b.txt", "deep/x/notlogs.log"},
+	},
+	{
+		"nested gitignore",
+		map[string]string{
+			"sub/.gitignore":   "*.gen\n/local/\n",
+			"sub/out.gen":      "gen\n",
+			"sub/deeper/c.gen": "gen\n",
+			"sub/local/x.txt":  "x\n",
+			"sub/main.go":      "package sub\n",
+			"other.gen":        "gen\n",
+			"local/y.txt":      "y\n",
+		},
+		[]string{"sub/out.gen", "sub/deeper/c.gen", "sub/local"},
+		[]string{"sub/.gitignore", "sub/main.go", "other.gen", "local/y.txt"},
+	},
+}
+
+func TestExtractGitignore(t *testing.T) {
+	for _, tt := range gitignoreTests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := writeExtractProject(t, tt.files)
+			defer os.RemoveAll(projectDir)
+
+			tmpDir, _ := ioutil.TempDir("", "skelp-extract-test")
+			defer os.RemoveAll(tmpDir)
+
+			if err := Extract(projectDir, tmpDir, ExtractOptions{Author: "brainicorn"}); err != nil {
+				t.Fatalf("error extracting project: %s", err)
+			}
+
+			tmplRoot := filepath.Join(tmpDir, "templates")
+
+			for _, rel := range tt.ignored {
+				if skelputil.PathExists(filepath.Join(tmplRoot, filepath.FromSlash(rel))) {
+					t.Errorf("ignored path %s should not have been extracted", rel)
+				}
+			}
+
+			for _, rel := range tt.kept {
+				if !skelputil.PathExists(filepath.Join(tmplRoot, filepath.FromSlash(rel))) {
+					t.Errorf("path %s should have been extracted", rel)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractCaseVariants(t *testing.T) {
+	replacements, err := extractReplacements(ExtractOptions{
+		Vars:         []ExtractVar{{Name: "projectName", Literal: "my-service"}},
+		CaseVariants: true,
+	})
+
+	if err != nil {
+		t.Fatalf("error building replacements: %s", err)
+	}
+
+	want := map[string]string{
+		"my-service": "{{.projectName}}",
+		"MyService":  "{{pascalCase .projectName}}",
+		"myService":  "{{camelCase .projectName}}",
+		"my_service": "{{snakeCase .projectName}}",
+		"MY_SERVICE": "{{screamingSnakeCase .projectName}}",
+		"MY-SERVICE": "{{upper .projectName}}",
+	}
+
+	have := make(map[string]string)
+	for _, r := range replacements {
+		have[r.literal] = r.expr
+	}
+
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("case variants do not match:\nhave (%v)\nwant (%v)", have, want)
+	}
+}
+
+func TestExtractDescriptor(t *testing.T) {
+	projectDir := writeExtractProject(t, extractProject)
+	defer os.RemoveAll(projectDir)
+
+	tmpDir, _ := ioutil.TempDir("", "skelp-extract-test")
+	defer os.RemoveAll(tmpDir)
+
+	opts := ExtractOptions{
+		Vars:        []ExtractVar{{Name: "projectName", Literal: "my-service"}},
+		Author:      "brainicorn",
+		Description: "a service",
+	}
+
+	if err := Extract(projectDir, tmpDir, opts); err != nil {
+		t.Fatalf("error extracting project: %s", err)
+	}
+
+	jsonBytes, _ := ioutil.ReadFile(filepath.Join(tmpDir, "skelp.json"))
+
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal(jsonBytes, &descriptor); err != nil {
+		t.Fatalf("error reading extracted descriptor: %s", err)
+	}
+
+	if descriptor.TemplateAuthor != "brainicorn" || descriptor.TemplateDesc != "a service" {
+		t.Errorf("descriptor info does not match: have (%s, %s)", descriptor.TemplateAuthor, descriptor.TemplateDesc)
+	}
+
+	if len(descriptor.TemplateVariables) != 1 || descriptor.TemplateVariables[0].Name() != "projectName" || descriptor.TemplateVariables[0].Default() != "my-service" {
+		t.Errorf("descriptor variables do not match: have (%v)", descriptor.TemplateVariables)
+	}
+
+	// without case variants only the literal is replaced
+	readme, _ := ioutil.ReadFile(filepath.Join(tmpDir, "templates", "README.md"))
+	if !strings.HasPrefix(string(readme), "# MyService\n\n{{.projectName}} is built by acme.") {
+		t.Errorf("extracted readme does not match:\n%s", readme)
+	}
+}
+
+var extractErrTests = []struct {
+	name string
+	vars []ExtractVar
+	err  string
+}{
+	{"invalid name", []ExtractVar{{Name: "my-name", Literal: "x"}}, "my-name is not a valid variable name"},
+	{"blank literal", []ExtractVar{{Name: "name", Literal: " "}}, "the text to replace with name can not be blank"},
+	{"duplicate", []ExtractVar{{Name: "name", Literal: "x"}, {Name: "name", Literal: "y"}}, "variable name was given more than once"},
+}
+
+func TestExtractErrors(t *testing.T) {
+	projectDir := writeExtractProject(t, extractProject)
+	defer os.RemoveAll(projectDir)
+
+	for _, tt := range extractErrTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, _ := ioutil.TempDir("", "skelp-extract-test")
+			defer os.RemoveAll(tmpDir)
+
+			err := Extract(projectDir, tmpDir, ExtractOptions{Vars: tt.vars, Author: "brainicorn"})
+
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("error does not match: have (%v) want (%s)", err, tt.err)
+			}
+
+			if skelputil.PathExists(filepath.Join(tmpDir, "skelp.json")) {
+				t.Errorf("skelp.json should not have been written")
+			}
+		})
+	}
+
+	err := Extract(projectDir, projectDir, ExtractOptions{Author: "brainicorn"})
+	if err == nil || !strings.HasPrefix(err.Error(), "the skelplate can not be extracted into the project itself") {
+		t.Errorf("error does not match: have (%v)", err)
+	}
+}
@@ -0,0 +1,615 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const (
+	errLintInvalidJSON      = "invalid json: %s"
+	errLintSchema           = "schema violation: %s"
+	errLintTemplate         = "unable to parse template: %s"
+	errLintDuplicateVar     = "variable %q is declared more than once"
+	errLintUndeclaredVar    = "variable %q is used but never declared"
+	errLintUnusedVar        = "variable %q is declared but never used"
+	errLintMinMaxNotAllowed = "variable %q: min and max don't apply to %s variables"
+	errLintMinOverMax       = "variable %q: min is greater than max"
+	errLintMultiline        = "variable %q: multiline doesn't apply to %s variables"
+	errLintDefaultType      = "variable %q: default %q is not a valid %s"
+	errLintDefaultConflict  = "invalid default for %s"
+	errLintSectionVar       = "section %q: variable %q is not declared"
+	errLintSectionTwice     = "section %q: variable %q is already in section %q"
+)
+
+var (
+	templateErrLineRegExp = regexp.MustCompile(`^template: [^:]*:(\d+):`)
+	contextLineRegExp     = regexp.MustCompile(`:(\d+):\d+$`)
+	fillerVarnames        = []string{"TemplateAuthor", "TemplateRepo", "TemplateCreated", "TemplateModified", "TemplateDesc"}
+)
+
+// LintProblem is an issue found in a skelplate by Lint.
+// Line is zero when the problem isn't tied to a line.
+type LintProblem struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (lp LintProblem) String() string {
+	if lp.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", lp.File, lp.Line, lp.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", lp.File, lp.Message)
+}
+
+// varReference is a variable used by a template.
+type varReference struct {
+	name string
+	file string
+	line int
+}
+
+// Lint checks the skelplate at templateRoot without applying it.
+// The descriptor is validated against the schema, every template in templateDirs is parsed and the
+// variables used by the templates are compared with the ones the descriptor declares. Variables
+// declared by included templates are used by them, included templates are found with resolve, or
+// relative to the including template when resolve is nil.
+// The error is only set when linting couldn't be done, problems with the skelplate are returned.
+func (sdp *SkelplateDataProvider) Lint(templateRoot string, templateDirs []string, resolve IncludeResolver) ([]LintProblem, error) {
+	var err error
+	var descriptorBytes []byte
+	var ownBytes []byte
+	var rawDescriptor map[string]interface{}
+	var schemaViolations []string
+	var descriptor SkelplateDescriptor
+
+	problems := []LintProblem{}
+	references := []varReference{}
+
+	jsonPath := filepath.Join(templateRoot, skelpFilename)
+
+	// a missing descriptor is reported by descriptorBytes
+	if skelputil.PathExists(jsonPath) {
+		ownBytes, err = ioutil.ReadFile(jsonPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// invalid json can't be merged with the template it extends so it's checked on its own first
+	if ownBytes != nil {
+		var ownDescriptor map[string]interface{}
+
+		if jerr := json.Unmarshal(ownBytes, &ownDescriptor); jerr != nil {
+			return append(problems, invalidJSONProblem(ownBytes, jerr)), nil
+		}
+	}
+
+	descriptorBytes, err = sdp.descriptorBytes(templateRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if jerr := json.Unmarshal(descriptorBytes, &rawDescriptor); jerr != nil {
+		return append(problems, invalidJSONProblem(ownBytes, jerr)), nil
+	}
+
+	schemaViolations, err = validateDescriptorSchema(descriptorBytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the descriptor can't be trusted beyond this point
+	if len(schemaViolations) > 0 {
+		for _, v := range schemaViolations {
+			problems = append(problems, LintProblem{File: skelpFilename, Message: fmt.Sprintf(errLintSchema, v)})
+		}
+
+		return problems, nil
+	}
+
+	err = json.Unmarshal(descriptorBytes, &descriptor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range lintVariables(descriptor.TemplateVariables, "") {
+		problems = append(problems, LintProblem{File: skelpFilename, Line: lineForVariable(ownBytes, msg.varname), Message: msg.message})
+	}
+
+	for _, msg := range lintSections(descriptor) {
+		problems = append(problems, LintProblem{File: skelpFilename, Message: msg})
+	}
+
+	descRefs, descProblems := sdp.descriptorReferences(rawDescriptor)
+	references = append(references, descRefs...)
+	problems = append(problems, descProblems...)
+
+	for _, tmplDir := range templateDirs {
+		fileRefs, fileProblems, werr := sdp.templateReferences(templateRoot, tmplDir)
+
+		if werr != nil {
+			return nil, werr
+		}
+
+		references = append(references, fileRefs...)
+		problems = append(problems, fileProblems...)
+	}
+
+	if resolve == nil {
+		resolve = localIncludePath
+	}
+
+	included := sdp.includedNames(templateRoot, descriptor.Includes, resolve, []string{templateRoot})
+	problems = append(problems, referenceProblems(descriptor.TemplateVariables, references, included, ownBytes)...)
+
+	return problems, nil
+}
+
+type varMessage struct {
+	varname string
+	message string
+}
+
+// lintVariables looks for duplicate names and defaults that break their own variable's rules.
+func lintVariables(vars []TemplateVariable, prefix string) []varMessage {
+	messages := []varMessage{}
+	seen := make(map[string]bool)
+
+	for _, v := range vars {
+		name := v.Name()
+		qualifiedName := prefix + name
+
+		if seen[name] {
+			messages = append(messages, varMessage{varname: name, message: fmt.Sprintf(errLintDuplicateVar, qualifiedName)})
+		}
+		seen[name] = true
+
+		if ov, ok := v.(*ObjectVar); ok {
+			messages = append(messages, lintVariables(ov.Variables, qualifiedName+".")...)
+			continue
+		}
+
+		if _, ok := v.(*Computed); ok {
+			continue
+		}
+
+		for _, msg := range lintDefault(v, qualifiedName) {
+			messages = append(messages, varMessage{varname: name, message: msg})
+		}
+	}
+
+	return messages
+}
+
+// lintSections looks for section variables that aren't top level variables or are in more than one section.
+func lintSections(descriptor SkelplateDescriptor) []string {
+	messages := []string{}
+	declared := make(map[string]bool)
+	owners := make(map[string]string)
+
+	for _, v := range descriptor.TemplateVariables {
+		declared[v.Name()] = true
+	}
+
+	for _, section := range descriptor.Sections {
+		for _, name := range section.Variables {
+			if !declared[name] {
+				messages = append(messages, fmt.Sprintf(errLintSectionVar, section.Title, name))
+				continue
+			}
+
+			if owner, owned := owners[name]; owned {
+				messages = append(messages, fmt.Sprintf(errLintSectionTwice, section.Title, name, owner))
+				continue
+			}
+
+			owners[name] = section.Title
+		}
+	}
+
+	return messages
+}
+
+func lintDefault(v TemplateVariable, qualifiedName string) []string {
+	messages := []string{}
+	defval := v.Default()
+	vtype := dataTypeFor(v, defval)
+	cv := complexVarFor(v)
+
+	if cv.Min != 0 || cv.Max != 0 {
+		switch vtype {
+		case VarTypeBool, VarTypeDate, VarTypePath:
+			messages = append(messages, fmt.Sprintf(errLintMinMaxNotAllowed, qualifiedName, vtype))
+		}
+
+		if cv.Max != 0 && cv.Min > cv.Max {
+			messages = append(messages, fmt.Sprintf(errLintMinOverMax, qualifiedName))
+		}
+	}
+
+	if cv.Multiline && vtype != VarTypeString {
+		messages = append(messages, fmt.Sprintf(errLintMultiline, qualifiedName, vtype))
+	}
+
+	// blank and templated defaults are only known when the template is applied
+	if isBlankOrTemplated(defval) || vtype == VarTypePath {
+		return messages
+	}
+
+	if !providedTypeMatches(defval, vtype, defval) {
+		return append(messages, fmt.Sprintf(errLintDefaultType, qualifiedName, stringForValue(defval), vtype))
+	}
+
+	sel, isSelection := v.(*Selection)
+	if isSelection && !skelputil.IsBlank(sel.Choices.Template) {
+		return messages
+	}
+
+	if isSelection {
+		for _, choice := range sel.Choices.Values() {
+			if strings.Contains(choice, "{{") {
+				return messages
+			}
+		}
+	}
+
+	if _, isSlice := defval.([]interface{}); isSlice && !isSelection {
+		return messages
+	}
+
+	for _, violation := range validateProvidedValue(v, qualifiedName, vtype, defval, defval, "") {
+		messages = append(messages, fmt.Sprintf(errLintDefaultConflict, violation))
+	}
+
+	return messages
+}
+
+// descriptorReferences parses every template string in the descriptor.
+func (sdp *SkelplateDataProvider) descriptorReferences(rawDescriptor map[string]interface{}) ([]varReference, []LintProblem) {
+	references := []varReference{}
+	problems := []LintProblem{}
+
+	for _, s := range templateStrings(rawDescriptor) {
+		refs, perr := sdp.parseReferences(skelpFilename, s)
+
+		if perr != nil {
+			problems = append(problems, LintProblem{File: skelpFilename, Message: fmt.Sprintf(errLintTemplate, perr)})
+			continue
+		}
+
+		// lines inside a single json string don't mean anything
+		for _, r := range refs {
+			references = append(references, varReference{name: r.name, file: skelpFilename})
+		}
+	}
+
+	return references, problems
+}
+
+// templateReferences parses every file and file name in a templates dir.
+func (sdp *SkelplateDataProvider) templateReferences(templateRoot, tmplDir string) ([]varReference, []LintProblem, error) {
+	references := []varReference{}
+	problems := []LintProblem{}
+
+	err := filepath.Walk(tmplDir, func(curPath string, fi os.FileInfo, werr error) error {
+		if werr != nil {
+			return werr
+		}
+
+		relPath, rerr := filepath.Rel(tmplDir, curPath)
+		displayPath, derr := filepath.Rel(templateRoot, curPath)
+
+		if rerr != nil || derr != nil || relPath == "." {
+			return rerr
+		}
+
+		if strings.Contains(fi.Name(), "{{") {
+			refs, perr := sdp.parseReferences(displayPath, fi.Name())
+
+			if perr != nil {
+				problems = append(problems, LintProblem{File: displayPath, Message: fmt.Sprintf(errLintTemplate, perr)})
+			}
+
+			for _, r := range refs {
+				references = append(references, varReference{name: r.name, file: displayPath})
+			}
+		}
+
+		if fi.IsDir() {
+			return nil
+		}
+
+		content, ferr := ioutil.ReadFile(curPath)
+
+		if ferr != nil {
+			return ferr
+		}
+
+		refs, perr := sdp.parseReferences(displayPath, string(content))
+
+		if perr != nil {
+			problems = append(problems, LintProblem{File: displayPath, Line: lineForTemplateError(perr), Message: fmt.Sprintf(errLintTemplate, perr)})
+		}
+
+		references = append(references, refs...)
+
+		return nil
+	})
+
+	return references, problems, err
+}
+
+// parseReferences parses a template with the configured funcs and returns the top level variables
+// it uses.
+func (sdp *SkelplateDataProvider) parseReferences(name, text string) ([]varReference, error) {
+	tmpl, err := template.New(name).Option(sdp.tOptions...).Funcs(sdp.funcMap).Parse(text)
+
+	if err != nil {
+		return nil, err
+	}
+
+	references := []varReference{}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			references = append(references, nodeReferences(t.Tree, t.Tree.Root, false)...)
+		}
+	}
+
+	return references, nil
+}
+
+// nodeReferences walks a parse tree collecting the fields used on the top level data.
+// Fields inside range and with blocks refer to something else once the dot has moved.
+func nodeReferences(tree *parse.Tree, node parse.Node, dotMoved bool) []varReference {
+	references := []varReference{}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return references
+		}
+
+		for _, child := range n.Nodes {
+			references = append(references, nodeReferences(tree, child, dotMoved)...)
+		}
+	case *parse.ActionNode:
+		references = append(references, nodeReferences(tree, n.Pipe, dotMoved)...)
+	case *parse.PipeNode:
+		if n == nil {
+			return references
+		}
+
+		for _, cmd := range n.Cmds {
+			references = append(references, nodeReferences(tree, cmd, dotMoved)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			references = append(references, nodeReferences(tree, arg, dotMoved)...)
+		}
+	case *parse.ChainNode:
+		references = append(references, nodeReferences(tree, n.Node, dotMoved)...)
+	case *parse.FieldNode:
+		if !dotMoved {
+			references = append(references, varReference{name: n.Ident[0], file: tree.ParseName, line: lineForNode(tree, n)})
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			references = append(references, varReference{name: n.Ident[1], file: tree.ParseName, line: lineForNode(tree, n)})
+		}
+	case *parse.IfNode:
+		references = append(references, nodeReferences(tree, n.Pipe, dotMoved)...)
+		references = append(references, nodeReferences(tree, n.List, dotMoved)...)
+		references = append(references, nodeReferences(tree, n.ElseList, dotMoved)...)
+	case *parse.RangeNode:
+		references = append(references, nodeReferences(tree, n.Pipe, dotMoved)...)
+		references = append(references, nodeReferences(tree, n.List, true)...)
+		references = append(references, nodeReferences(tree, n.ElseList, dotMoved)...)
+	case *parse.WithNode:
+		references = append(references, nodeReferences(tree, n.Pipe, dotMoved)...)
+		references = append(references, nodeReferences(tree, n.List, true)...)
+		references = append(references, nodeReferences(tree, n.ElseList, dotMoved)...)
+	case *parse.TemplateNode:
+		references = append(references, nodeReferences(tree, n.Pipe, dotMoved)...)
+	}
+
+	return references
+}
+
+// referenceProblems compares the variables the templates use with the ones that are declared.
+func referenceProblems(vars []TemplateVariable, references []varReference, included map[string]bool, descriptorBytes []byte) []LintProblem {
+	problems := []LintProblem{}
+	declared := make(map[string]bool)
+	used := make(map[string]bool)
+	reported := make(map[string]bool)
+
+	for _, name := range fillerVarnames {
+		declared[name] = true
+	}
+
+	for _, name := range declaredNames(vars) {
+		declared[name] = true
+	}
+
+	for _, r := range references {
+		used[r.name] = true
+
+		key := fmt.Sprintf("%s:%d:%s", r.file, r.line, r.name)
+		if !declared[r.name] && !reported[key] {
+			reported[key] = true
+			problems = append(problems, LintProblem{File: r.file, Line: r.line, Message: fmt.Sprintf(errLintUndeclaredVar, r.name)})
+		}
+	}
+
+	for _, v := range vars {
+		name := v.Name()
+		if !used[name] && !included[name] && !strings.Contains(name, "{{") && !reported[name] {
+			reported[name] = true
+			problems = append(problems, LintProblem{File: skelpFilename, Line: lineForVariable(descriptorBytes, name), Message: fmt.Sprintf(errLintUnusedVar, name)})
+		}
+	}
+
+	return problems
+}
+
+// includedNames returns the names of the variables declared by the included templates and the
+// templates they include. They get their values from the including template's data unless the
+// include's data maps them. chain holds the templates that include these ones.
+func (sdp *SkelplateDataProvider) includedNames(templateRoot string, includes []Include, resolve IncludeResolver, chain []string) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, inc := range includes {
+		var err error
+		var incRoot string
+		var descriptor SkelplateDescriptor
+
+		incRoot, err = resolve(templateRoot, inc.TemplateID)
+
+		if err == nil {
+			descriptor, err = sdp.readDescriptor(incRoot)
+		}
+
+		// includes that can't be read, break the schema or include each other are reported when the
+		// template is generated
+		if err != nil || containsString(chain, incRoot) {
+			continue
+		}
+
+		incNames := sdp.includedNames(incRoot, descriptor.Includes, resolve, append(append([]string{}, chain...), incRoot))
+		for _, v := range descriptor.TemplateVariables {
+			incNames[v.Name()] = true
+		}
+
+		for name := range incNames {
+			if _, isMapped := inc.Data[name]; !isMapped {
+				names[name] = true
+			}
+		}
+	}
+
+	return names
+}
+
+// declaredNames returns the names of the variables and of the variables nested in objects, which
+// can be used by the templates in the descriptor.
+func declaredNames(vars []TemplateVariable) []string {
+	names := []string{}
+
+	for _, v := range vars {
+		names = append(names, v.Name())
+
+		if ov, ok := v.(*ObjectVar); ok {
+			names = append(names, declaredNames(ov.Variables)...)
+		}
+	}
+
+	return names
+}
+
+// templateStrings returns every string in a json structure that contains a template.
+func templateStrings(val interface{}) []string {
+	strs := []string{}
+
+	switch tval := val.(type) {
+	case string:
+		if strings.Contains(tval, "{{") {
+			strs = append(strs, tval)
+		}
+	case []interface{}:
+		for _, elem := range tval {
+			strs = append(strs, templateStrings(elem)...)
+		}
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range tval {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			strs = append(strs, templateStrings(tval[k])...)
+		}
+	}
+
+	return strs
+}
+
+func isBlankOrTemplated(val interface{}) bool {
+	if vals, ok := val.([]interface{}); ok {
+		for _, elem := range vals {
+			if isBlankOrTemplated(elem) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	s := stringForValue(val)
+
+	return skelputil.IsBlank(s) || strings.Contains(s, "{{")
+}
+
+func lineForNode(tree *parse.Tree, node parse.Node) int {
+	location, _ := tree.ErrorContext(node)
+
+	if m := contextLineRegExp.FindStringSubmatch(location); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+
+	return 0
+}
+
+func lineForTemplateError(err error) int {
+	if m := templateErrLineRegExp.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+
+	return 0
+}
+
+// lineForVariable finds the line in the descriptor where a variable is named.
+func lineForVariable(descriptorBytes []byte, varname string) int {
+	nameRegExp := regexp.MustCompile(`"name"\s*:\s*` + regexp.QuoteMeta(strconv.Quote(varname)))
+
+	if loc := nameRegExp.FindIndex(descriptorBytes); loc != nil {
+		return lineForOffset(descriptorBytes, int64(loc[0]))
+	}
+
+	return 0
+}
+
+func invalidJSONProblem(ownBytes []byte, jerr error) LintProblem {
+	line := 0
+	if serr, ok := jerr.(*json.SyntaxError); ok {
+		line = lineForOffset(ownBytes, serr.Offset)
+	}
+
+	return LintProblem{File: skelpFilename, Line: line, Message: fmt.Sprintf(errLintInvalidJSON, jerr)}
+}
+
+func lineForOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
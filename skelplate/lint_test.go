@@ -0,0 +1,140 @@
+package skelplate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintSimpleSkelplate(t *testing.T) {
+	root, _ := filepath.Abs("../testdata/generator/simple")
+	dp := NewDataProvider(nil)
+
+	problems, err := dp.Lint(root, []string{filepath.Join(root, "templates")}, nil)
+
+	if err != nil {
+		t.Fatalf("lint error: %s", err)
+	}
+
+	if len(problems) > 0 {
+		t.Errorf("expected no problems, have (%v)", problems)
+	}
+}
+
+func TestLintProblems(t *testing.T) {
+	root, _ := filepath.Abs("../testdata/lint/broken")
+	dp := NewDataProvider(nil)
+
+	problems, err := dp.Lint(root, []string{filepath.Join(root, "templates")}, nil)
+
+	if err != nil {
+		t.Fatalf("lint error: %s", err)
+	}
+
+	expected := []string{
+		`skelp.json:5: variable "projectName" is declared more than once`,
+		`skelp.json:13: invalid default for beer: "hefeweizen" must have a max length of 5`,
+		`skelp.json:18: invalid default for cheese: "cheddar" is not one of the available choices (gouda,brie)`,
+		`skelp.json:24: variable "abv": multiline doesn't apply to float variables`,
+		`skelp.json: section "Drinks": variable "wine" is not declared`,
+		`skelp.json: section "Food": variable "beer" is already in section "Drinks"`,
+		"templates/broken.txt:",
+		`templates/README.md:3: variable "brewer" is used but never declared`,
+		`skelp.json:29: variable "unused" is declared but never used`,
+	}
+
+	if len(problems) != len(expected) {
+		t.Fatalf("wrong number of problems: have (%d) want (%d)\n%v", len(problems), len(expected), problems)
+	}
+
+	for i, p := range problems {
+		if !strings.HasPrefix(p.String(), expected[i]) {
+			t.Errorf("wrong problem: have (%s) want (%s)", p, expected[i])
+		}
+	}
+}
+
+func TestLintSchemaViolations(t *testing.T) {
+	root, _ := filepath.Abs("../testdata/generator/baddescriptor")
+	dp := NewDataProvider(nil)
+
+	problems, err := dp.Lint(root, []string{}, nil)
+
+	if err != nil {
+		t.Fatalf("lint error: %s", err)
+	}
+
+	if len(problems) < 1 || problems[0].File != skelpFilename {
+		t.Errorf("expected schema violations, have (%v)", problems)
+	}
+}
+
+func TestLintInvalidJSONUnderExtends(t *testing.T) {
+	root, _ := ioutil.TempDir("", "skelp-lint-test")
+	defer os.RemoveAll(root)
+
+	baseRoot, _ := filepath.Abs("../testdata/generator/extends/base")
+	ioutil.WriteFile(filepath.Join(root, skelpFilename), []byte("{\n  \"extends\": \"../base\",\n  \"variables\": [\n}\n"), 0644)
+
+	dp := NewDataProvider(nil)
+	dp.parents[root] = baseRoot
+
+	problems, err := dp.Lint(root, []string{}, nil)
+
+	if err != nil {
+		t.Fatalf("lint error: %s", err)
+	}
+
+	if len(problems) != 1 || !strings.HasPrefix(problems[0].String(), "skelp.json:4: invalid json") {
+		t.Errorf("expected an invalid json problem, have (%v)", problems)
+	}
+}
+
+func TestLintVariablesSharedWithIncludes(t *testing.T) {
+	root, _ := filepath.Abs("../testdata/generator/includes/service")
+	dp := NewDataProvider(nil)
+
+	problems, err := dp.Lint(root, []string{filepath.Join(root, "templates")}, nil)
+
+	if err != nil {
+		t.Fatalf("lint error: %s", err)
+	}
+
+	if len(problems) > 0 {
+		t.Errorf("expected no problems, have (%v)", problems)
+	}
+
+	// without the included template year is never used
+	notFound := func(templateRoot, templateID string) (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	problems, err = dp.Lint(root, []string{filepath.Join(root, "templates")}, notFound)
+
+	if err != nil || len(problems) != 1 || problems[0].Message != `variable "year" is declared but never used` {
+		t.Errorf("expected year to be unused, have (%v, %v)", problems, err)
+	}
+}
+
+func TestLintSkipsIncludesThatBreakTheSchema(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "skelp-lint-include-test")
+	defer os.RemoveAll(dir)
+
+	descriptors := map[string]string{
+		"broken":    `{"author": 5, "variables": []}`,
+		"including": `{"author": "brainicorn", "variables": [{"name": "year", "default": "2017"}], "includes": [{"template": "../broken"}]}`,
+	}
+
+	for name, descriptor := range descriptors {
+		os.MkdirAll(filepath.Join(dir, name), 0755)
+		ioutil.WriteFile(filepath.Join(dir, name, skelpFilename), []byte(descriptor), 0644)
+	}
+
+	problems, err := NewDataProvider(nil).Lint(filepath.Join(dir, "including"), []string{}, nil)
+
+	if err != nil || len(problems) != 1 || problems[0].Message != `variable "year" is declared but never used` {
+		t.Errorf("expected year to be unused, have (%v, %v)", problems, err)
+	}
+}
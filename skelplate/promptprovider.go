@@ -0,0 +1,298 @@
+package skelplate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brainicorn/skelp/prompter"
+)
+
+const (
+	ErrPromptAnswerType    = "the answer for %s (%v) is not a %s"
+	ErrPromptAnswerInvalid = "the answer for %s (%v) is not valid: %s"
+)
+
+// Kinds of VariablePrompt
+const (
+	PromptSimple     = typeSimple
+	PromptComplex    = typeComplex
+	PromptSelect     = typeSelect
+	PromptMultiVal   = typeMultiVal
+	PromptAddAnother = "addAnother"
+	PromptReview     = "review"
+	PromptMultiline  = "multiline"
+)
+
+// VariablePrompt describes a variable that needs an answer.
+type VariablePrompt struct {
+	// Name is the qualified name of the variable, e.g. owner.email or taps[0].style
+	Name string
+
+	// Kind is one of the Prompt* kinds. PromptAddAnother asks whether another item of a repeated
+	// object should be added. PromptReview lists every answer as a choice, see ReviewGenerate.
+	// PromptMultiline asks for a string that can span several lines.
+	Kind string
+
+	// Type is the data type of the answer.
+	Type string
+
+	// Question is the rendered question to display.
+	Question string
+
+	// Help is displayed when the user asks for help. It includes DocsURL when there is one.
+	Help string
+
+	// DocsURL points at documentation for the variable.
+	DocsURL string
+
+	// Default is the rendered default value. It's a []interface{} for variables with multiple values.
+	Default interface{}
+
+	// Password is set when the answer should not be displayed.
+	Password bool
+
+	// Required is set when the answer can't be blank or, for a PromptSelect, when something must be picked.
+	Required bool
+
+	// Layout is the time layout of date variables.
+	Layout string
+
+	// Choices are the options of a PromptSelect or PromptReview prompt.
+	Choices []Choice
+
+	// MultipleChoice allows picking more than one of the Choices, between MinPicks and MaxPicks when they're set.
+	MultipleChoice bool
+	MinPicks       int
+	MaxPicks       int
+
+	// AddPrompt is the question asked after each value of a PromptMultiVal variable.
+	AddPrompt string
+
+	// Validators check an answer given as a string. Multi-value answers are checked one value at a time.
+	Validators []prompter.Validator
+
+	// Section is the section the variable belongs to, or nil. SectionStart is set on the first prompt
+	// of each section.
+	Section      *Section
+	SectionStart bool
+
+	// Number is the position of the prompt among the Total prompts expected. Both are 0 for
+	// PromptAddAnother prompts. The total changes as repeated objects get items and sections are skipped.
+	Number int
+	Total  int
+}
+
+// PromptProvider asks for the values of variables that aren't provided by the data.
+//
+// The answer must be of the variable's type: string, int, float64, bool or time.Time.
+// Variables with a []interface{} default are answered with a []interface{} of that type,
+// PromptAddAnother is answered with a bool and PromptReview with the value of the chosen choice.
+type PromptProvider interface {
+	PromptForVariable(vp VariablePrompt) (interface{}, error)
+}
+
+// TerminalPromptProvider asks for values on a terminal. It's the default PromptProvider.
+type TerminalPromptProvider struct {
+	BeforePrompt func()
+	// Terminal is asked instead of stdin and stdout when set.
+	Terminal prompter.Terminal
+	// Editor edits PromptMultiline answers, see prompter.DefaultEditor. It's only used without a Terminal.
+	Editor string
+}
+
+func (tpp *TerminalPromptProvider) PromptForVariable(vp VariablePrompt) (interface{}, error) {
+	var ask prompter.Prompter
+	var askAgain prompter.Prompter
+
+	if vp.SectionStart {
+		header := &prompter.Header{
+			Prompt:      prompter.Prompt{Terminal: tpp.Terminal},
+			Title:       vp.Section.Title,
+			Description: vp.Section.Description,
+		}
+
+		if err := header.Show(); err != nil {
+			return nil, err
+		}
+	}
+
+	question := vp.Question
+	if vp.Number > 0 && vp.Total > 0 {
+		question = fmt.Sprintf(promptProgress, vp.Number, vp.Total, vp.Question)
+	}
+
+	prompt := prompter.Prompt{
+		Question:     question,
+		Help:         vp.Help,
+		Default:      defaultForPrompt(vp.Default),
+		Validators:   vp.Validators,
+		BeforePrompt: tpp.BeforePrompt,
+		Terminal:     tpp.Terminal,
+	}
+
+	switch vp.Kind {
+	case PromptSelect, PromptReview:
+		labels := []string{}
+		values := []string{}
+		descriptions := []string{}
+		for _, c := range vp.Choices {
+			labels = append(labels, c.DisplayLabel())
+			values = append(values, c.Value)
+			descriptions = append(descriptions, c.Help)
+		}
+
+		ask = &prompter.SelectedInput{
+			Prompt:       prompt,
+			Options:      labels,
+			Values:       values,
+			Descriptions: descriptions,
+			IsMulti:      vp.MultipleChoice,
+			MinPicks:     vp.MinPicks,
+			MaxPicks:     vp.MaxPicks,
+		}
+
+	case PromptMultiline:
+		multi := &prompter.MultilineInput{Prompt: prompt}
+
+		// the editor takes over the real terminal
+		if tpp.Terminal == nil {
+			multi.Editor = tpp.Editor
+		}
+
+		ask = multi
+
+	case PromptMultiVal:
+		ask = &prompter.KeyedInput{Prompt: prompt}
+
+		askAgain = &prompter.KeyedInput{
+			Prompt: prompter.Prompt{
+				Question: vp.AddPrompt,
+				Default:  "y",
+				Terminal: tpp.Terminal,
+			},
+			IsConfirm: true,
+		}
+
+	default:
+		ask = &prompter.KeyedInput{Prompt: prompt, IsConfirm: vp.Type == VarTypeBool, IsPassword: vp.Password}
+	}
+
+	return doPrompt(ask, askAgain, tpp.BeforePrompt, vp.Type, vp.Layout, vp.Default)
+}
+
+// promptProvider returns the PromptProvider set on the provider or a TerminalPromptProvider.
+func (sdp *SkelplateDataProvider) promptProvider() PromptProvider {
+	if sdp.prompts != nil {
+		return sdp.prompts
+	}
+
+	return &TerminalPromptProvider{BeforePrompt: sdp.beforePrompt, Terminal: sdp.terminal, Editor: prompter.DefaultEditor()}
+}
+
+func (sdp *SkelplateDataProvider) askToAddAnother(ov *ObjectVar, varname string) (bool, error) {
+	vp := addAnotherPrompt(ov, varname)
+	sdp.describePosition(&vp, false)
+
+	ans, err := sdp.promptProvider().PromptForVariable(vp)
+
+	if err != nil {
+		return false, err
+	}
+
+	again, ok := ans.(bool)
+
+	if !ok {
+		return false, fmt.Errorf(ErrPromptAnswerType, varname, ans, VarTypeBool)
+	}
+
+	return again, nil
+}
+
+// checkAnswer makes sure an answer from the PromptProvider has the type of the variable and passes
+// the validators of the prompt, the way answers typed on the terminal do. Selections must pick from
+// the choices, the way provided data must.
+func checkAnswer(vp VariablePrompt, ans interface{}) error {
+	if !answerTypeMatches(ans, vp.Type, vp.Default) {
+		return fmt.Errorf(ErrPromptAnswerType, vp.Name, ans, vp.Type)
+	}
+
+	if vp.Kind == PromptSelect {
+		values := []string{}
+		for _, c := range vp.Choices {
+			values = append(values, c.Value)
+		}
+
+		violations := pickViolations(providedChoices(ans), values, vp.MultipleChoice, vp.Required, vp.MinPicks, vp.MaxPicks)
+		if len(violations) > 0 {
+			return fmt.Errorf(ErrPromptAnswerInvalid, vp.Name, ans, strings.Join(violations, ", "))
+		}
+	}
+
+	answers := []interface{}{ans}
+	if vals, isSlice := ans.([]interface{}); isSlice {
+		answers = vals
+	}
+
+	for _, a := range answers {
+		for _, validator := range vp.Validators {
+			if verr := validator(answerString(a, vp.Layout)); verr != nil {
+				return fmt.Errorf(ErrPromptAnswerInvalid, vp.Name, ans, strings.TrimSuffix(verr.Error(), ", please try again."))
+			}
+		}
+	}
+
+	return nil
+}
+
+// answerTypeMatches checks that an answer has the go type expected for the variable.
+func answerTypeMatches(ans interface{}, vtype string, defval interface{}) bool {
+	if _, isSlice := defval.([]interface{}); isSlice {
+		vals, ok := ans.([]interface{})
+		if !ok {
+			return false
+		}
+
+		for _, elem := range vals {
+			if !scalarAnswerMatches(elem, vtype) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return scalarAnswerMatches(ans, vtype)
+}
+
+func scalarAnswerMatches(ans interface{}, vtype string) bool {
+	var ok bool
+
+	switch vtype {
+	case VarTypeInt:
+		_, ok = ans.(int)
+	case VarTypeFloat:
+		_, ok = ans.(float64)
+	case VarTypeBool:
+		_, ok = ans.(bool)
+	case VarTypeDate:
+		_, ok = ans.(time.Time)
+	default:
+		_, ok = ans.(string)
+	}
+
+	return ok
+}
+
+// answerString returns the answer the way it would have been typed on the terminal.
+func answerString(ans interface{}, layout string) string {
+	if t, isTime := ans.(time.Time); isTime {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(layout)
+	}
+
+	return stringForValue(ans)
+}
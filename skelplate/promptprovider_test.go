@@ -0,0 +1,231 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/brainicorn/skelp/prompter"
+)
+
+type fakePromptProvider struct {
+	answers []interface{}
+	asked   []VariablePrompt
+}
+
+func (fpp *fakePromptProvider) PromptForVariable(vp VariablePrompt) (interface{}, error) {
+	var ans interface{}
+
+	fpp.asked = append(fpp.asked, vp)
+	ans, fpp.answers = fpp.answers[0], fpp.answers[1:]
+
+	return ans, nil
+}
+
+const promptProviderDescriptorJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "beer", "default": "ipa", "prompt": "favorite beer?", "required": true},
+    {"name": "style", "default": "{{.beer}}", "choices": [{"label": "India Pale Ale", "value": "ipa", "help": "hoppy"}, "stout"]},
+    {"name": "tags", "default": ["hoppy"], "mutlival": true},
+    {"name": "secret", "default": "", "password": true},
+    {"name": "taps", "repeated": true, "variables": [{"name": "abv", "default": 5}]}
+  ]
+}`
+
+func TestPromptProvider(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(promptProviderDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	fpp := &fakePromptProvider{answers: []interface{}{
+		"stout", "stout", []interface{}{"dark", "roasty"}, "shh", float64(6), true, float64(4.5), false,
+	}}
+
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	data, err := dp.gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	for k := range builtinData(descriptor) {
+		delete(data, k)
+	}
+
+	want := map[string]interface{}{
+		"beer":   "stout",
+		"style":  "stout",
+		"tags":   []interface{}{"dark", "roasty"},
+		"secret": "shh",
+		"taps": []interface{}{
+			map[string]interface{}{"abv": float64(6)},
+			map[string]interface{}{"abv": float64(4.5)},
+		},
+	}
+
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("wrong data:\nhave (%v)\nwant (%v)", data, want)
+	}
+
+	var tests = []struct {
+		name     string
+		kind     string
+		vtype    string
+		question string
+		def      interface{}
+	}{
+		{"beer", PromptComplex, VarTypeString, "favorite beer?", "ipa"},
+		{"style", PromptSelect, VarTypeString, "Make a selection for style:", "stout"},
+		{"tags", PromptMultiVal, VarTypeString, "Enter a value for tags:", []interface{}{"hoppy"}},
+		{"secret", PromptComplex, VarTypeString, "Enter a value for secret:", ""},
+		{"taps[0].abv", PromptSimple, VarTypeFloat, "Enter a value for taps[0].abv:", float64(5)},
+		{"taps", PromptAddAnother, VarTypeBool, "Would you like to add another taps:", true},
+		{"taps[1].abv", PromptSimple, VarTypeFloat, "Enter a value for taps[1].abv:", float64(5)},
+		{"taps", PromptAddAnother, VarTypeBool, "Would you like to add another taps:", true},
+	}
+
+	if len(fpp.asked) != len(tests) {
+		t.Fatalf("wrong number of prompts, have (%d) want (%d)", len(fpp.asked), len(tests))
+	}
+
+	for i, tt := range tests {
+		vp := fpp.asked[i]
+
+		if vp.Name != tt.name || vp.Kind != tt.kind || vp.Type != tt.vtype || vp.Question != tt.question || !reflect.DeepEqual(vp.Default, tt.def) {
+			t.Errorf("%d: wrong prompt, have (%s %s %s %q %v) want (%s %s %s %q %v)", i,
+				vp.Name, vp.Kind, vp.Type, vp.Question, vp.Default, tt.name, tt.kind, tt.vtype, tt.question, tt.def)
+		}
+	}
+
+	if len(fpp.asked[0].Validators) < 1 || fpp.asked[0].Validators[0]("") == nil {
+		t.Errorf("required variable should not accept a blank answer")
+	}
+
+	if !reflect.DeepEqual(fpp.asked[1].Choices, []Choice{{Label: "India Pale Ale", Value: "ipa", Help: "hoppy"}, {Value: "stout"}}) {
+		t.Errorf("wrong choices: %v", fpp.asked[1].Choices)
+	}
+
+	if fpp.asked[2].AddPrompt != "Would you like to add another value for tags:" {
+		t.Errorf("wrong add prompt: %s", fpp.asked[2].AddPrompt)
+	}
+
+	if !fpp.asked[3].Password {
+		t.Errorf("password variable should be masked")
+	}
+}
+
+var promptAnswerTests = []struct {
+	variable string
+	answer   interface{}
+	expected string
+}{
+	{`{"name":"beer","default":"ipa"}`, float64(6), "the answer for beer (6) is not a string"},
+	{`{"name":"port","type":"int","default":8080}`, float64(80), "the answer for port (80) is not a int"},
+	{`{"name":"tags","default":["hoppy"],"mutlival":true}`, "dark", "the answer for tags (dark) is not a string"},
+	{`{"name":"beer","default":"ipa","required":true}`, " ", "the answer for beer ( ) is not valid"},
+	{`{"name":"beer","default":"ipa","max":3}`, "porter", "the answer for beer (porter) is not valid"},
+	{`{"name":"tags","default":["ipa"],"mutlival":true}`, []interface{}{"ipa", 6}, "the answer for tags ([ipa 6]) is not a string"},
+	{`{"name":"port","type":"int","default":8080,"min":1024}`, 80, "the answer for port (80) is not valid"},
+	{`{"name":"port","type":"int","default":8080,"min":1024}`, 8081, ""},
+	{`{"name":"brewed","type":"date","default":"2017-01-02"}`, time.Date(2017, 2, 3, 0, 0, 0, 0, time.UTC), ""},
+	{`{"name":"style","default":"ipa","choices":["ipa","stout"]}`, "lager", "the answer for style (lager) is not valid"},
+	{`{"name":"style","default":"ipa","choices":["ipa","stout"]}`, "stout", ""},
+	{`{"name":"hops","default":["citra"],"choices":["citra","mosaic","simcoe"],"mutlichoice":true,"maxPicks":2}`, []interface{}{"citra", "mosaic", "simcoe"}, "the answer for hops ([citra mosaic simcoe]) is not valid"},
+	{`{"name":"hops","default":["citra"],"choices":["citra","mosaic","simcoe"],"mutlichoice":true,"minPicks":1}`, []interface{}{}, "the answer for hops ([]) is not valid"},
+	{`{"name":"hops","default":["citra"],"choices":["citra","mosaic","simcoe"],"mutlichoice":true,"required":true}`, []interface{}{"mosaic"}, ""},
+}
+
+func TestPromptProviderAnswerChecked(t *testing.T) {
+	for i, tt := range promptAnswerTests {
+		var descriptor SkelplateDescriptor
+		err := json.Unmarshal([]byte(`{"author":"brainicorn","variables":[`+tt.variable+`]}`), &descriptor)
+
+		if err != nil {
+			t.Fatalf("%d: error reading descriptor: %s", i, err)
+		}
+
+		dp := NewDataProvider(nil)
+		dp.SetPromptProvider(&fakePromptProvider{answers: []interface{}{tt.answer}})
+
+		_, err = dp.gatherData(descriptor)
+
+		if tt.expected == "" && err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		}
+
+		if tt.expected != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.expected)) {
+			t.Errorf("%d: wrong error, have (%v) want (%s)", i, err, tt.expected)
+		}
+	}
+}
+
+func TestMultilinePrompt(t *testing.T) {
+	core.DisableColor = true
+
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(`{"author":"brainicorn","variables":[
+		{"name":"intro","default":"a fine ale","multiline":true},
+		{"name":"abv","default":5,"multiline":true}
+	]}`), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	fpp := &fakePromptProvider{answers: []interface{}{"hoppy\nand bitter", float64(6)}}
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	if _, err = dp.gatherData(descriptor); err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	if fpp.asked[0].Kind != PromptMultiline || fpp.asked[1].Kind != PromptComplex {
+		t.Errorf("wrong kinds: have (%s, %s) want (%s, %s)", fpp.asked[0].Kind, fpp.asked[1].Kind, PromptMultiline, PromptComplex)
+	}
+
+	term := prompter.NewScriptedTerminal("hoppy", "and bitter", "", "")
+	dp = NewDataProvider(nil)
+	dp.SetTerminal(term)
+
+	data, err := dp.gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	if data["intro"] != "hoppy\nand bitter" {
+		t.Errorf("wrong answer: have (%q) want (%q)", data["intro"], "hoppy\nand bitter")
+	}
+}
+
+func TestSkelpFuncsInDefaults(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(`{"author":"brainicorn","variables":[
+		{"name":"projectName","default":"Hoppy Brew"},
+		{"name":"{{.projectName | goPackage}}Dir","default":"{{.projectName | kebabCase}}"}
+	]}`), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	fpp := &fakePromptProvider{answers: []interface{}{"Hoppy Brew", "hoppy-brew"}}
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	if _, err = dp.gatherData(descriptor); err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	if fpp.asked[1].Name != "hoppybrewDir" || fpp.asked[1].Default != "hoppy-brew" {
+		t.Errorf("wrong prompt: have (%s, %v) want (hoppybrewDir, hoppy-brew)", fpp.asked[1].Name, fpp.asked[1].Default)
+	}
+}
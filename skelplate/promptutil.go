@@ -2,9 +2,9 @@ package skelplate
 
 import (
 	"fmt"
-	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/brainicorn/skelp/prompter"
 	"github.com/brainicorn/skelp/skelputil"
@@ -14,66 +14,89 @@ const (
 	promptEnterValue    = "Enter a value for %s:"
 	promptMakeSelection = "Make a selection for %s:"
 	promptAddAnother    = "Would you like to add another value for %s:"
+	promptAddObject     = "Would you like to add another %s:"
+	helpWithDocs        = "%s (see %s)"
+	helpDocsOnly        = "see %s"
+	promptProgress      = "(%d/%d) %s"
 )
 
-func promptForVariable(tvar TemplateVariable, varname string, dval interface{}, beforePrompt func()) (interface{}, error) {
-	var ask prompter.Prompter
-	var askAgain prompter.Prompter
-
-	prompt := prompter.Prompt{BeforePrompt: beforePrompt}
-
-	defIsBool := reflect.TypeOf(dval).Kind() == reflect.Bool
+// newVariablePrompt describes the variable to a PromptProvider.
+func newVariablePrompt(tvar TemplateVariable, varname string, dval interface{}, outputDir string) VariablePrompt {
+	var prompt prompter.Prompt
+
+	vtype := dataTypeFor(tvar, dval)
+	cv := complexVarFor(tvar)
+
+	vp := VariablePrompt{
+		Name:     varname,
+		Kind:     PromptSimple,
+		Type:     vtype,
+		Default:  dval,
+		Password: cv.Password,
+		Required: cv.Required,
+		Layout:   dateLayoutFor(cv),
+	}
 
-	switch tvar.(type) {
+	switch ttv := tvar.(type) {
 	case *SimpleVar:
-		ttv := tvar.(*SimpleVar)
-		cplxVar := ComplexVar{
-			SimpleVar: *ttv,
-		}
-		configurePrompt(&prompt, cplxVar, varname, promptEnterValue, dval)
-		ask = &prompter.KeyedInput{Prompt: prompt, IsConfirm: defIsBool}
+		configurePrompt(&prompt, cv, varname, promptEnterValue, vtype, dval, outputDir)
 
 	case *ComplexVar:
-		ttv := tvar.(*ComplexVar)
-		configurePrompt(&prompt, *ttv, varname, promptEnterValue, dval)
-		ask = &prompter.KeyedInput{Prompt: prompt, IsConfirm: defIsBool, IsPassword: ttv.Password}
+		vp.Kind = PromptComplex
+		configurePrompt(&prompt, cv, varname, promptEnterValue, vtype, dval, outputDir)
+
+		if cv.Multiline && !cv.Password && vtype == VarTypeString {
+			vp.Kind = PromptMultiline
+		}
 
 	case *MultiValue:
-		ttv := tvar.(*MultiValue)
-		configurePrompt(&prompt, ttv.ComplexVar, varname, promptEnterValue, dval)
-		ask = &prompter.KeyedInput{Prompt: prompt, IsConfirm: false}
+		vp.Kind = PromptMultiVal
+		configurePrompt(&prompt, cv, varname, promptEnterValue, vtype, dval, outputDir)
 
-		secondQuestion := fmt.Sprintf(promptAddAnother, varname)
+		vp.AddPrompt = fmt.Sprintf(promptAddAnother, varname)
 		if !skelputil.IsBlank(ttv.AddPrompt) {
-			secondQuestion = ttv.AddPrompt
-		}
-
-		askAgain = &prompter.KeyedInput{
-			Prompt: prompter.Prompt{
-				Question: secondQuestion,
-				Default:  "y",
-			},
-			IsConfirm: true,
+			vp.AddPrompt = ttv.AddPrompt
 		}
 
 	case *Selection:
-		ttv := tvar.(*Selection)
-		configurePrompt(&prompt, ttv.ComplexVar, varname, promptMakeSelection, dval)
+		vp.Kind = PromptSelect
+		configurePrompt(&prompt, cv, varname, promptMakeSelection, vtype, dval, outputDir)
 
-		ask = &prompter.SelectedInput{
-			Prompt:  prompt,
-			Options: ttv.Choices,
-			IsMulti: ttv.MultipleChoice,
-		}
+		vp.Choices = ttv.Choices.Options
+		vp.MultipleChoice = ttv.MultipleChoice
+		vp.MinPicks = ttv.MinPicks
+		vp.MaxPicks = ttv.MaxPicks
+	}
+
+	vp.Question = prompt.Question
+	vp.Help = prompt.Help
+	vp.DocsURL = cv.DocsURL
+	vp.Validators = prompt.Validators
+
+	return vp
+}
+
+// addAnotherPrompt describes the question asked after each item of a repeated object.
+func addAnotherPrompt(ov *ObjectVar, varname string) VariablePrompt {
+	question := fmt.Sprintf(promptAddObject, varname)
+	if !skelputil.IsBlank(ov.AddPrompt) {
+		question = ov.AddPrompt
 	}
 
-	return doPrompt(ask, askAgain, beforePrompt, dval)
+	return VariablePrompt{
+		Name:     varname,
+		Kind:     PromptAddAnother,
+		Type:     VarTypeBool,
+		Question: question,
+		Default:  true,
+	}
 }
 
-func configurePrompt(prompt *prompter.Prompt, cv ComplexVar, varname, fallbackQuestion string, defval interface{}) {
+func configurePrompt(prompt *prompter.Prompt, cv ComplexVar, varname, fallbackQuestion, vtype string, defval interface{}, outputDir string) {
 	prompt.Question = formatQuestion(cv, varname, fallbackQuestion)
+	prompt.Help = formatHelp(cv)
 	prompt.Validators = []prompter.Validator{}
-	configureDefaultAndValidators(prompt, cv, defval)
+	configureDefaultAndValidators(prompt, cv, vtype, defval, outputDir)
 }
 
 func formatQuestion(cv ComplexVar, varname, fallback string) string {
@@ -86,12 +109,43 @@ func formatQuestion(cv ComplexVar, varname, fallback string) string {
 	return question
 }
 
-func configureDefaultAndValidators(prompt *prompter.Prompt, cv ComplexVar, defval interface{}) {
-	var defstring string
+// defaultForPrompt formats a default value the way a prompt displays it.
+// Multi-value defaults are joined with commas.
+func defaultForPrompt(defval interface{}) string {
+	if defslice, ok := defval.([]interface{}); ok {
+		defstrings := []string{}
+		for _, elem := range defslice {
+			defstrings = append(defstrings, stringForValue(elem))
+		}
+
+		return strings.Join(defstrings, ",")
+	}
+
+	return stringForValue(defval)
+}
+
+// formatHelp combines the help and docs url of a variable into the text displayed on request.
+func formatHelp(cv ComplexVar) string {
+	switch {
+	case skelputil.IsBlank(cv.DocsURL):
+		return cv.Help
+	case skelputil.IsBlank(cv.Help):
+		return fmt.Sprintf(helpDocsOnly, cv.DocsURL)
+	}
+
+	return fmt.Sprintf(helpWithDocs, cv.Help, cv.DocsURL)
+}
+
+func configureDefaultAndValidators(prompt *prompter.Prompt, cv ComplexVar, vtype string, defval interface{}, outputDir string) {
+	prompt.Default = defaultForPrompt(defval)
 
-	switch defval.(type) {
-	case string:
-		defstring = defval.(string)
+	// each entry of a multi-value default is left unchecked
+	if _, ok := defval.([]interface{}); ok {
+		return
+	}
+
+	switch vtype {
+	case VarTypeString:
 		if cv.Required {
 			prompt.Validators = append(prompt.Validators, prompter.StringNotBlank)
 		}
@@ -104,14 +158,17 @@ func configureDefaultAndValidators(prompt *prompter.Prompt, cv ComplexVar, defva
 			prompt.Validators = append(prompt.Validators, mm.CheckMin)
 			prompt.Validators = append(prompt.Validators, mm.CheckMax)
 		}
-	case float64:
-		defstring = strconv.FormatFloat(defval.(float64), 'f', -1, 64)
+	case VarTypeInt, VarTypeFloat:
 		if cv.Required {
 			prompt.Validators = append(prompt.Validators, prompter.GreaterThanZero)
 		} else {
 			prompt.Validators = append(prompt.Validators, prompter.IsANumber)
 		}
 
+		if vtype == VarTypeInt {
+			prompt.Validators = append(prompt.Validators, prompter.IsAnInteger)
+		}
+
 		if cv.Min > 0 || cv.Max > 0 {
 			mm := &prompter.MinMaxNumber{
 				Min: cv.Min,
@@ -120,26 +177,32 @@ func configureDefaultAndValidators(prompt *prompter.Prompt, cv ComplexVar, defva
 			prompt.Validators = append(prompt.Validators, mm.CheckMin)
 			prompt.Validators = append(prompt.Validators, mm.CheckMax)
 		}
-	case bool:
-		defstring = strconv.FormatBool(defval.(bool))
-	case []interface{}:
-		defslice := []string{}
-		for _, elem := range defval.([]interface{}) {
-			if reflect.TypeOf(elem).Kind() == reflect.String {
-				defslice = append(defslice, elem.(string))
-			}
+	case VarTypeDate:
+		if cv.Required {
+			prompt.Validators = append(prompt.Validators, prompter.StringNotBlank)
+		}
 
-			if reflect.TypeOf(elem).Kind() == reflect.Float64 {
-				defslice = append(defslice, strconv.FormatFloat(elem.(float64), 'f', -1, 64))
-			}
+		dl := &prompter.DateLayout{Layout: dateLayoutFor(cv)}
+		prompt.Validators = append(prompt.Validators, dl.CheckDate)
+	case VarTypePath:
+		if cv.Required {
+			prompt.Validators = append(prompt.Validators, prompter.StringNotBlank)
+		}
+
+		pc := &prompter.PathCheck{
+			MustExist: cv.MustExist,
+			IsDir:     cv.IsDir,
+		}
+
+		if cv.RelativeToOutput {
+			pc.BaseDir = outputDir
 		}
-		defstring = strings.Join(defslice, ",")
-	}
 
-	prompt.Default = defstring
+		prompt.Validators = append(prompt.Validators, pc.CheckPath)
+	}
 }
 
-func doPrompt(ask, askAgain prompter.Prompter, beforePrompt func(), defval interface{}) (interface{}, error) {
+func doPrompt(ask, askAgain prompter.Prompter, beforePrompt func(), vtype, layout string, defval interface{}) (interface{}, error) {
 	var err error
 	var answer string
 	var finalAnswer interface{}
@@ -169,44 +232,106 @@ func doPrompt(ask, askAgain prompter.Prompter, beforePrompt func(), defval inter
 	}
 
 	if err == nil {
-		finalAnswer, err = convertAnswer(answer, defval)
+		finalAnswer, err = convertAnswer(answer, vtype, layout, defval)
 	}
 
 	return finalAnswer, err
 }
 
-func convertAnswer(answer string, defval interface{}) (interface{}, error) {
+func convertAnswer(answer, vtype, layout string, defval interface{}) (interface{}, error) {
+	if _, ok := defval.([]interface{}); ok {
+		var err error
+		var tans interface{}
+		typedSlice := []interface{}{}
+
+		for _, s := range strings.Split(answer, ",") {
+			tans, err = convertValue(s, vtype, layout)
+
+			if err != nil {
+				return nil, err
+			}
+
+			typedSlice = append(typedSlice, tans)
+		}
+
+		return typedSlice, err
+	}
+
+	return convertValue(answer, vtype, layout)
+}
+
+func convertValue(answer, vtype, layout string) (interface{}, error) {
 	var err error
 	var typedAnswer interface{}
 
-	switch defval.(type) {
-	case string:
+	switch vtype {
+	case VarTypeInt:
+		typedAnswer, err = strconv.Atoi(strings.TrimSpace(answer))
+	case VarTypeFloat:
+		typedAnswer, err = strconv.ParseFloat(strings.TrimSpace(answer), 64)
+	case VarTypeBool:
+		typedAnswer, err = strconv.ParseBool(answer)
+	case VarTypeDate:
+		if skelputil.IsBlank(answer) {
+			typedAnswer = time.Time{}
+		} else {
+			typedAnswer, err = time.Parse(layout, strings.TrimSpace(answer))
+		}
+	default:
 		typedAnswer = answer
+	}
+
+	return typedAnswer, err
+}
+
+// typedDefault converts a rendered default to the type of the variable, the way accepting the
+// default at a prompt would. List defaults and defaults that don't convert are returned as is.
+func typedDefault(v TemplateVariable, vtype string, defval interface{}) interface{} {
+	if _, isSlice := defval.([]interface{}); isSlice {
+		return defval
+	}
+
+	typed, err := convertValue(stringForValue(defval), vtype, dateLayoutFor(complexVarFor(v)))
+
+	if err != nil {
+		return defval
+	}
+
+	return typed
+}
+
+// dataTypeFor returns the declared type of the variable or infers one from the default value.
+func dataTypeFor(tvar TemplateVariable, defval interface{}) string {
+	if vtype := declaredType(tvar); !skelputil.IsBlank(vtype) {
+		return vtype
+	}
+
+	return inferDataType(defval)
+}
+
+func inferDataType(val interface{}) string {
+	switch tval := val.(type) {
 	case float64:
-		typedAnswer, err = strconv.ParseFloat(answer, 64)
+		return VarTypeFloat
+	case int:
+		return VarTypeInt
 	case bool:
-		typedAnswer, err = strconv.ParseBool(answer)
+		return VarTypeBool
+	case time.Time:
+		return VarTypeDate
 	case []interface{}:
-		ansSlice := strings.Split(answer, ",")
-		var tans interface{}
-		typedSlice := []interface{}{}
+		if len(tval) > 0 {
+			return inferDataType(tval[0])
+		}
+	}
 
-		switch reflect.TypeOf(defval.([]interface{})[0]).Kind() {
-		case reflect.String:
-			for _, s := range ansSlice {
-				typedSlice = append(typedSlice, s)
-			}
-		case reflect.Float64:
-			for _, s := range ansSlice {
-				tans, err = strconv.ParseFloat(s, 64)
+	return VarTypeString
+}
 
-				if err == nil {
-					typedSlice = append(typedSlice, tans)
-				}
-			}
-		}
-		typedAnswer = typedSlice
+func dateLayoutFor(cv ComplexVar) string {
+	if skelputil.IsBlank(cv.Layout) {
+		return DefaultDateLayout
 	}
 
-	return typedAnswer, err
+	return cv.Layout
 }
@@ -0,0 +1,117 @@
+package skelplate
+
+import (
+	"time"
+)
+
+// RecordedData returns the answers gathered for the template and the templates it includes in a
+// form that can be written to a json data file and provided to a later run as is.
+// The Template* values, computed variables that can't be overridden and passwords are left out.
+// When an included template answers a variable the including template already answered, the
+// including template's answer is kept.
+func (sdp *SkelplateDataProvider) RecordedData() map[string]interface{} {
+	return sdp.recorded
+}
+
+// record adds the gathered values of vars to the recorded data. varnames holds the rendered name
+// of each variable in vars.
+func (sdp *SkelplateDataProvider) record(vars []TemplateVariable, varnames []string, data map[string]interface{}) {
+	for i, v := range vars {
+		name := varnames[i]
+
+		if _, exists := sdp.recorded[name]; exists {
+			continue
+		}
+
+		if val, ok := recordValue(v, data[name], false); ok {
+			sdp.recorded[name] = val
+		}
+	}
+}
+
+// recordValue returns the value as it would be provided in a data file, or false if the variable
+// should not be recorded. Passwords are only recorded when withPasswords is set.
+func recordValue(v TemplateVariable, val interface{}, withPasswords bool) (interface{}, bool) {
+	if computed, ok := v.(*Computed); ok && !computed.Overridable {
+		return nil, false
+	}
+
+	cv := complexVarFor(v)
+
+	if cv.Password && !withPasswords {
+		return nil, false
+	}
+
+	if ov, ok := v.(*ObjectVar); ok {
+		return recordObject(ov, val, withPasswords), true
+	}
+
+	switch tval := val.(type) {
+	case time.Time:
+		return tval.Format(dateLayoutFor(cv)), true
+	case []interface{}:
+		vals := []interface{}{}
+		for _, item := range tval {
+			if d, isDate := item.(time.Time); isDate {
+				vals = append(vals, d.Format(dateLayoutFor(cv)))
+			} else {
+				vals = append(vals, item)
+			}
+		}
+
+		return vals, true
+	}
+
+	return val, true
+}
+
+// recordObject records each item of an object variable. Nested variables are matched to the item
+// values by their unrendered names.
+func recordObject(ov *ObjectVar, val interface{}, withPasswords bool) interface{} {
+	recordItem := func(item interface{}) interface{} {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return item
+		}
+
+		recorded := make(map[string]interface{})
+		for k, v := range itemMap {
+			recorded[k] = v
+		}
+
+		for _, nv := range ov.Variables {
+			if nval, exists := itemMap[nv.Name()]; exists {
+				if rval, keep := recordValue(nv, nval, withPasswords); keep {
+					recorded[nv.Name()] = rval
+				} else {
+					delete(recorded, nv.Name())
+				}
+			}
+		}
+
+		return recorded
+	}
+
+	if !ov.Repeated {
+		return recordItem(val)
+	}
+
+	switch tval := val.(type) {
+	case []interface{}:
+		items := []interface{}{}
+		for _, item := range tval {
+			items = append(items, recordItem(item))
+		}
+
+		return items
+	case map[string]interface{}:
+		items := make(map[string]interface{})
+		for k, item := range tval {
+			items[k] = recordItem(item)
+		}
+
+		return items
+	}
+
+	return val
+}
@@ -0,0 +1,133 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/AlecAivazis/survey/core"
+)
+
+const recordDescriptorJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "beer", "default": "ipa"},
+    {"name": "abv", "type": "int", "default": 5},
+    {"name": "hoppy", "default": true},
+    {"name": "brewed", "type": "date", "default": "2017-01-02"},
+    {"name": "secret", "default": "", "password": true},
+    {"name": "styles", "default": ["ipa"], "mutlichoice": true, "choices": ["ipa", "stout", "lager"]},
+    {"name": "tags", "default": ["hoppy"], "mutlival": true},
+    {"name": "loud", "value": "{{.beer | upper}}", "computed": true},
+    {"name": "hops", "repeated": true, "key": "hopName", "variables": [{"name": "hopName", "default": "citra"}, {"name": "pin", "default": "", "password": true}]}
+  ]
+}`
+
+func TestRecordedDataReplays(t *testing.T) {
+	core.DisableColor = true
+
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(recordDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	user := newFakeInterruptingUser([]string{
+		"stout", "7", "n", "2018-03-04", "shh", " \x0e \x0e ", "dark", "n", "mosaic", "1234", "n",
+	})
+	defer user.done()
+
+	dp := NewDataProvider(nil)
+	dp.beforePrompt = user.nextKeystroke
+
+	answered, err := dp.gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	recordedBytes, err := json.Marshal(dp.RecordedData())
+
+	if err != nil {
+		t.Fatalf("error writing recorded data: %s", err)
+	}
+
+	var recorded map[string]interface{}
+	json.Unmarshal(recordedBytes, &recorded)
+
+	want := map[string]interface{}{
+		"beer":   "stout",
+		"abv":    float64(7),
+		"hoppy":  false,
+		"brewed": "2018-03-04",
+		"styles": []interface{}{"stout", "lager"},
+		"tags":   []interface{}{"dark"},
+		"hops":   map[string]interface{}{"mosaic": map[string]interface{}{"hopName": "mosaic"}},
+	}
+
+	if !reflect.DeepEqual(recorded, want) {
+		t.Errorf("recorded data does not match:\nhave (%v)\nwant (%v)", recorded, want)
+	}
+
+	// replaying only asks for the passwords that were left out
+	replayUser := newFakeInterruptingUser([]string{"shh", "1234"})
+	defer replayUser.done()
+
+	replay := NewDataProvider(recorded)
+	replay.beforePrompt = replayUser.nextKeystroke
+
+	replayed, err := replay.gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error replaying data: %s", err)
+	}
+
+	if len(replayUser.keystrokes) > 0 {
+		t.Errorf("replay did not ask for the passwords")
+	}
+
+	if !reflect.DeepEqual(replayed, answered) {
+		t.Errorf("replayed data does not match:\nhave (%v)\nwant (%v)", replayed, answered)
+	}
+}
+
+func TestRecordedDatesUseLayout(t *testing.T) {
+	core.DisableColor = true
+
+	var descriptor SkelplateDescriptor
+	json.Unmarshal([]byte(`{"author": "brainicorn", "variables": [
+		{"name": "tastings", "type": "date", "layout": "02/01/2006", "default": ["01/02/2017"], "mutlival": true}
+	]}`), &descriptor)
+
+	user := newFakeInterruptingUser([]string{"03/04/2018", "y", "05/06/2018", "n"})
+	defer user.done()
+
+	dp := NewDataProvider(nil)
+	dp.beforePrompt = user.nextKeystroke
+
+	answered, err := dp.gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	recordedBytes, _ := json.Marshal(dp.RecordedData())
+
+	var recorded map[string]interface{}
+	json.Unmarshal(recordedBytes, &recorded)
+
+	want := map[string]interface{}{"tastings": []interface{}{"03/04/2018", "05/06/2018"}}
+
+	if !reflect.DeepEqual(recorded, want) {
+		t.Errorf("recorded data does not match:\nhave (%v)\nwant (%v)", recorded, want)
+	}
+
+	replayed, err := NewDataProvider(recorded).gatherData(descriptor)
+
+	if err != nil {
+		t.Fatalf("error replaying data: %s", err)
+	}
+
+	if !reflect.DeepEqual(replayed, answered) {
+		t.Errorf("replayed data does not match:\nhave (%v)\nwant (%v)", replayed, answered)
+	}
+}
@@ -0,0 +1,254 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ErrReviewCancelled = "cancelled while reviewing the answers"
+
+	// ReviewGenerate and ReviewCancel are the values of the first and last choices of a PromptReview.
+	// The other choices hold the name of the variable to change.
+	ReviewGenerate = ""
+	ReviewCancel   = "!cancel"
+
+	promptReview     = "Review your answers, pick one to change:"
+	reviewGenerate   = "looks good, generate"
+	reviewCancel     = "cancel"
+	reviewMask       = "****"
+	reviewLabel      = "%-*s  %s"
+	reviewAnswerName = "review"
+)
+
+// reviewEntry is an answer that can be changed during the review.
+type reviewEntry struct {
+	name string
+	v    TemplateVariable
+}
+
+// reviewData lets the user change answers until they choose to generate or cancel.
+// Changing an answer asks again for it and for every later variable whose name, default, choices or
+// section condition depends on it. recordedBefore holds the recorded names that belong to other templates.
+func (sdp *SkelplateDataProvider) reviewData(descriptor SkelplateDescriptor, data map[string]interface{}, recordedBefore map[string]bool) (map[string]interface{}, error) {
+	for {
+		entries, err := sdp.reviewEntries(descriptor.TemplateVariables, data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ans, err := sdp.promptProvider().PromptForVariable(reviewPrompt(entries, data))
+
+		if err != nil {
+			return nil, fmt.Errorf("error asking for input: (%s): %s", reviewAnswerName, err)
+		}
+
+		choice, _ := ans.(string)
+
+		switch choice {
+		case ReviewGenerate:
+			return data, nil
+		case ReviewCancel:
+			return nil, errors.New(ErrReviewCancelled)
+		}
+
+		data, err = sdp.changeAnswer(descriptor, entries, data, choice, recordedBefore)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// reviewEntries returns the top level variables that were answered, with their rendered names.
+// Computed variables that can't be overridden are left out.
+func (sdp *SkelplateDataProvider) reviewEntries(vars []TemplateVariable, data map[string]interface{}) ([]reviewEntry, error) {
+	entries := []reviewEntry{}
+
+	for _, v := range vars {
+		name, err := sdp.runStringTemplate(v.Name(), data)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse variable name template: %s - %s", v.Name(), err)
+		}
+
+		if computed, ok := v.(*Computed); ok && !computed.Overridable {
+			continue
+		}
+
+		entries = append(entries, reviewEntry{name: name, v: v})
+	}
+
+	return entries, nil
+}
+
+// reviewPrompt lists every answer as a choice between generating and cancelling.
+func reviewPrompt(entries []reviewEntry, data map[string]interface{}) VariablePrompt {
+	width := 0
+	for _, e := range entries {
+		if len(e.name) > width {
+			width = len(e.name)
+		}
+	}
+
+	choices := []Choice{{Label: reviewGenerate, Value: ReviewGenerate}}
+
+	for _, e := range entries {
+		choices = append(choices, Choice{Label: fmt.Sprintf(reviewLabel, width, e.name, reviewValue(e, data[e.name])), Value: e.name})
+	}
+
+	choices = append(choices, Choice{Label: reviewCancel, Value: ReviewCancel})
+
+	return VariablePrompt{
+		Name:     reviewAnswerName,
+		Kind:     PromptReview,
+		Type:     VarTypeString,
+		Question: promptReview,
+		Default:  ReviewGenerate,
+		Choices:  choices,
+	}
+}
+
+// reviewValue formats an answer for display. Passwords are masked.
+func reviewValue(e reviewEntry, val interface{}) string {
+	if complexVarFor(e.v).Password {
+		return reviewMask
+	}
+
+	recorded, _ := recordValue(e.v, val, false)
+
+	switch tval := recorded.(type) {
+	case string:
+		return tval
+	case []interface{}:
+		vals := []string{}
+		for _, elem := range tval {
+			vals = append(vals, stringForValue(elem))
+		}
+
+		if _, isObject := e.v.(*ObjectVar); !isObject {
+			return strings.Join(vals, ", ")
+		}
+	}
+
+	display, _ := json.Marshal(recorded)
+
+	return string(display)
+}
+
+// changeAnswer gathers the data again keeping every answer except the changed one and the answers
+// that depend on it. Kept answers are used as they are, they aren't run as templates or checked again.
+func (sdp *SkelplateDataProvider) changeAnswer(descriptor SkelplateDescriptor, entries []reviewEntry, data map[string]interface{}, changed string, recordedBefore map[string]bool) (map[string]interface{}, error) {
+	stale, err := sdp.staleAnswers(descriptor, entries, changed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]interface{})
+	for k, v := range sdp.data {
+		if !stale[k] {
+			answers[k] = v
+		}
+	}
+
+	kept := make(map[string]interface{})
+	for _, e := range entries {
+		if val, ok := data[e.name]; ok && !stale[e.name] {
+			kept[e.name] = val
+		}
+	}
+
+	for name := range sdp.recorded {
+		if !recordedBefore[name] {
+			delete(sdp.recorded, name)
+		}
+	}
+
+	provided := sdp.data
+	sdp.data, sdp.kept = answers, kept
+	data, err = sdp.gatherData(descriptor)
+	sdp.data, sdp.kept = provided, nil
+
+	return data, err
+}
+
+// staleAnswers returns the name of the changed answer and of every answer whose variable templates,
+// or whose section's when template, use a stale answer.
+func (sdp *SkelplateDataProvider) staleAnswers(descriptor SkelplateDescriptor, entries []reviewEntry, changed string) (map[string]bool, error) {
+	stale := map[string]bool{changed: true}
+	refs := make(map[string][]string)
+
+	sections := newSectionTracker(descriptor.Sections)
+
+	for _, e := range entries {
+		var err error
+
+		refs[e.name], err = sdp.variableReferences(e.v)
+
+		if section := sections.byVar[e.v.Name()]; err == nil && section != nil {
+			var whenRefs []varReference
+			whenRefs, err = sdp.parseReferences(section.Title, section.When)
+
+			for _, r := range whenRefs {
+				refs[e.name] = append(refs[e.name], r.name)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for grew := true; grew; {
+		grew = false
+
+		for _, e := range entries {
+			if stale[e.name] {
+				continue
+			}
+
+			for _, ref := range refs[e.name] {
+				if stale[ref] {
+					stale[e.name] = true
+					grew = true
+					break
+				}
+			}
+		}
+	}
+
+	return stale, nil
+}
+
+// variableReferences returns the names used by the templates of a variable.
+func (sdp *SkelplateDataProvider) variableReferences(v TemplateVariable) ([]string, error) {
+	var err error
+	var varBytes []byte
+	var raw interface{}
+
+	names := []string{}
+	varBytes, err = json.Marshal(v)
+
+	if err == nil {
+		err = json.Unmarshal(varBytes, &raw)
+	}
+
+	for _, s := range templateStrings(raw) {
+		if err != nil {
+			break
+		}
+
+		var refs []varReference
+		refs, err = sdp.parseReferences(v.Name(), s)
+
+		for _, r := range refs {
+			names = append(names, r.name)
+		}
+	}
+
+	return names, err
+}
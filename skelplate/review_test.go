@@ -0,0 +1,151 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const reviewDescriptorJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "beer", "default": "ipa"},
+    {"name": "brewery", "default": "acme"},
+    {"name": "repo", "default": "{{.brewery}}/beers"},
+    {"name": "secret", "default": "", "password": true},
+    {"name": "loud", "value": "{{.brewery | upper}}", "computed": true},
+    {"name": "food", "default": "pizza"}
+  ]
+}`
+
+func TestReviewChangesAnswer(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(reviewDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	fpp := &fakePromptProvider{answers: []interface{}{
+		"ale", "acme", "acme/beers", "shh", "tacos",
+		"brewery", "hops", "hops/ales",
+		ReviewGenerate,
+	}}
+
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	data, err := dp.gatherData(descriptor)
+
+	if err == nil {
+		data, err = dp.reviewData(descriptor, data, map[string]bool{})
+	}
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	for k := range builtinData(descriptor) {
+		delete(data, k)
+	}
+
+	want := map[string]interface{}{"beer": "ale", "brewery": "hops", "repo": "hops/ales", "secret": "shh", "loud": "HOPS", "food": "tacos"}
+
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("wrong data:\nhave (%v)\nwant (%v)", data, want)
+	}
+
+	asked := []string{}
+	for _, vp := range fpp.asked {
+		asked = append(asked, vp.Name)
+	}
+
+	wantAsked := []string{"beer", "brewery", "repo", "secret", "food", "review", "brewery", "repo", "review"}
+	if !reflect.DeepEqual(asked, wantAsked) {
+		t.Errorf("wrong prompts:\nhave (%v)\nwant (%v)", asked, wantAsked)
+	}
+
+	if fpp.asked[7].Default != "hops/beers" {
+		t.Errorf("dependent default was not updated: have (%v)", fpp.asked[7].Default)
+	}
+
+	labels := []string{}
+	for _, c := range fpp.asked[5].Choices {
+		labels = append(labels, c.Label)
+	}
+
+	wantLabels := []string{"looks good, generate", "beer     ale", "brewery  acme", "repo     acme/beers", "secret   ****", "food     tacos", "cancel"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("wrong review choices:\nhave (%q)\nwant (%q)", labels, wantLabels)
+	}
+
+	if dp.RecordedData()["brewery"] != "hops" {
+		t.Errorf("recorded data was not updated: have (%v)", dp.RecordedData())
+	}
+}
+
+func TestReviewCancel(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(reviewDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	fpp := &fakePromptProvider{answers: []interface{}{"ale", "acme", "acme/beers", "shh", "tacos", ReviewCancel}}
+
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	data, err := dp.gatherData(descriptor)
+
+	if err == nil {
+		_, err = dp.reviewData(descriptor, data, map[string]bool{})
+	}
+
+	if err == nil || err.Error() != ErrReviewCancelled {
+		t.Errorf("wrong error: have (%v) want (%s)", err, ErrReviewCancelled)
+	}
+}
+
+func TestReviewKeepsAnswersAsTyped(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	err := json.Unmarshal([]byte(`{"author":"brainicorn","variables":[
+		{"name":"beer","default":"ipa"},
+		{"name":"motto","default":""},
+		{"name":"abv","type":"int","default":5},
+		{"name":"brewed","type":"date","default":"2017-01-02"}
+	]}`), &descriptor)
+
+	if err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	brewed := time.Date(2017, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	fpp := &fakePromptProvider{answers: []interface{}{
+		"ale", "{{.beer}} is the best beer", 6, brewed,
+		"beer", "stout",
+		ReviewGenerate,
+	}}
+
+	dp := NewDataProvider(nil)
+	dp.SetPromptProvider(fpp)
+
+	data, err := dp.gatherData(descriptor)
+
+	if err == nil {
+		data, err = dp.reviewData(descriptor, data, map[string]bool{})
+	}
+
+	if err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	for k := range builtinData(descriptor) {
+		delete(data, k)
+	}
+
+	want := map[string]interface{}{"beer": "stout", "motto": "{{.beer}} is the best beer", "abv": 6, "brewed": brewed}
+
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("wrong data:\nhave (%v)\nwant (%v)", data, want)
+	}
+}
@@ -0,0 +1,419 @@
+package skelplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const sampleIndent = "  "
+
+// SampleValue is an entry of a sample data file.
+// Leaf entries hold a Value, object entries hold Fields and repeated objects are written as a list
+// with one item, or as a map with one item when they are keyed.
+type SampleValue struct {
+	Name     string
+	Value    interface{}
+	Comment  string
+	Fields   []SampleValue
+	IsObject bool
+	Repeated bool
+	Key      string
+}
+
+// IncludeResolver returns the local path of a template included by the template at templateRoot.
+type IncludeResolver func(templateRoot, templateID string) (string, error)
+
+// localIncludePath resolves an included template id as a path relative to the including template.
+func localIncludePath(templateRoot, templateID string) (string, error) {
+	if filepath.IsAbs(templateID) {
+		return templateID, nil
+	}
+
+	return filepath.Join(templateRoot, templateID), nil
+}
+
+// SampleData builds an entry for every variable a data file can provide, in descriptor order,
+// followed by the variables of included templates that the data file can provide.
+// Names and defaults are rendered the same way gatherData renders them and computed variables
+// are left out unless they can be overridden. Included templates are found with resolve, or
+// relative to the including template when resolve is nil.
+func (sdp *SkelplateDataProvider) SampleData(templateRoot string, resolve IncludeResolver) ([]SampleValue, error) {
+	if resolve == nil {
+		resolve = localIncludePath
+	}
+
+	return sdp.sampleTemplate(templateRoot, map[string]interface{}{}, resolve, []string{})
+}
+
+// sampleTemplate samples the variables of the template at templateRoot that aren't shared by an
+// including template, then the variables of the templates it includes. The data file is shared by
+// every template so a name is only sampled once and names mapped by an include's data are left out.
+func (sdp *SkelplateDataProvider) sampleTemplate(templateRoot string, shared map[string]interface{}, resolve IncludeResolver, chain []string) ([]SampleValue, error) {
+	var err error
+	var descriptor SkelplateDescriptor
+	var samples []SampleValue
+
+	// cycles are reported when the template is generated
+	for _, root := range chain {
+		if root == templateRoot {
+			return []SampleValue{}, nil
+		}
+	}
+
+	descriptor, err = sdp.readDescriptor(templateRoot)
+
+	scope := make(map[string]interface{})
+	for k, v := range shared {
+		scope[k] = v
+	}
+
+	for k, v := range builtinData(descriptor) {
+		scope[k] = v
+	}
+
+	if err == nil {
+		samples, err = sdp.sampleVariables(descriptor.TemplateVariables, scope, shared)
+	}
+
+	for _, inc := range descriptor.Includes {
+		if err != nil {
+			break
+		}
+
+		var incRoot string
+		var incSamples []SampleValue
+
+		incShared := make(map[string]interface{})
+		for k, v := range scope {
+			incShared[k] = v
+		}
+
+		for k, v := range inc.Data {
+			if incShared[k], err = sdp.runStringTemplate(v, scope); err != nil {
+				return nil, fmt.Errorf("unable to parse include data template: %s - %s", v, err)
+			}
+		}
+
+		incRoot, err = resolve(templateRoot, inc.TemplateID)
+
+		if err == nil {
+			incSamples, err = sdp.sampleTemplate(incRoot, incShared, resolve, append(chain, templateRoot))
+		}
+
+		for _, s := range incSamples {
+			if !sampled(samples, s.Name) {
+				samples = append(samples, s)
+			}
+		}
+	}
+
+	return samples, err
+}
+
+func sampled(samples []SampleValue, name string) bool {
+	for _, s := range samples {
+		if s.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sampleVariables samples vars in scope. Variables found in shared get their value from the
+// including template and are left out.
+func (sdp *SkelplateDataProvider) sampleVariables(vars []TemplateVariable, scope, shared map[string]interface{}) ([]SampleValue, error) {
+	samples := []SampleValue{}
+
+	for _, v := range vars {
+		var defval interface{}
+		varname, err := sdp.runStringTemplate(v.Name(), scope)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse variable name template: %s - %s", v.Name(), err)
+		}
+
+		if _, isShared := shared[varname]; isShared {
+			continue
+		}
+
+		if ov, ok := v.(*ObjectVar); ok {
+			var sample SampleValue
+			sample, err = sdp.sampleObject(ov, varname, scope)
+
+			if err != nil {
+				return nil, err
+			}
+
+			samples = append(samples, sample)
+			continue
+		}
+
+		defval, err = sdp.renderDefault(v, scope)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if sel, ok := v.(*Selection); ok {
+			v, err = sdp.resolveChoices(sel, scope)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse variable choices template: %s - %s", varname, err)
+			}
+		}
+
+		vtype := dataTypeFor(v, defval)
+		sampleVal := defval
+
+		// data files hold numbers and bools as json values, everything else as strings
+		scope[varname] = typedDefault(v, vtype, defval)
+
+		if _, isDate := scope[varname].(time.Time); !isDate {
+			sampleVal = scope[varname]
+		}
+
+		if computed, ok := v.(*Computed); ok && !computed.Overridable {
+			continue
+		}
+
+		samples = append(samples, SampleValue{Name: varname, Value: sampleVal, Comment: sampleComment(v, varname, vtype)})
+	}
+
+	return samples, nil
+}
+
+func (sdp *SkelplateDataProvider) sampleObject(ov *ObjectVar, varname string, scope map[string]interface{}) (SampleValue, error) {
+	itemScope := make(map[string]interface{})
+	for k, v := range scope {
+		itemScope[k] = v
+	}
+
+	fields, err := sdp.sampleVariables(ov.Variables, itemScope, nil)
+
+	if err != nil {
+		return SampleValue{}, err
+	}
+
+	item := make(map[string]interface{})
+	for _, f := range fields {
+		item[f.Name] = itemScope[f.Name]
+	}
+
+	scope[varname] = item
+
+	if ov.Repeated {
+		scope[varname] = []interface{}{item}
+
+		if !skelputil.IsBlank(ov.Key) {
+			scope[varname] = map[string]interface{}{stringForValue(item[ov.Key]): item}
+		}
+	}
+
+	comment := ""
+	if ov.Repeated {
+		comment = fmt.Sprintf(promptAddObject, varname)
+		if !skelputil.IsBlank(ov.AddPrompt) {
+			comment = ov.AddPrompt
+		}
+	}
+
+	return SampleValue{Name: varname, Fields: fields, IsObject: true, Repeated: ov.Repeated, Key: ov.Key, Comment: comment}, nil
+}
+
+// sampleComment describes the question and rules of a variable.
+func sampleComment(v TemplateVariable, varname, vtype string) string {
+	cv := complexVarFor(v)
+	rules := []string{vtype}
+
+	question := cv.Prompt
+	if skelputil.IsBlank(question) {
+		question = fmt.Sprintf(promptEnterValue, varname)
+	}
+
+	if cv.Required {
+		rules = append(rules, "required")
+	}
+
+	if cv.Min != 0 {
+		rules = append(rules, fmt.Sprintf("min %v", cv.Min))
+	}
+
+	if cv.Max != 0 {
+		rules = append(rules, fmt.Sprintf("max %v", cv.Max))
+	}
+
+	switch tv := v.(type) {
+	case *Selection:
+		if skelputil.IsBlank(cv.Prompt) {
+			question = fmt.Sprintf(promptMakeSelection, varname)
+		}
+
+		rules = append(rules, "one of "+strings.Join(tv.Choices.Values(), ", "))
+
+		if tv.MultipleChoice {
+			rules = append(rules, "multiple choice")
+		}
+	case *MultiValue:
+		rules = append(rules, "list")
+	case *Computed:
+		question = "computed from " + tv.Value
+	}
+
+	return fmt.Sprintf("%s (%s)", question, strings.Join(rules, ", "))
+}
+
+// SampleJSON writes the samples as a json document that keeps the descriptor order.
+// Json has no comments so only the values are written, SampleYAML annotates each value.
+func SampleJSON(samples []SampleValue) ([]byte, error) {
+	var b bytes.Buffer
+
+	err := writeSampleJSON(&b, samples, "")
+
+	if err == nil {
+		b.WriteString("\n")
+	}
+
+	return b.Bytes(), err
+}
+
+func writeSampleJSON(b *bytes.Buffer, samples []SampleValue, indent string) error {
+	inner := indent + sampleIndent
+
+	b.WriteString("{")
+
+	for i, s := range samples {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		b.WriteString("\n" + inner)
+		name, _ := json.Marshal(s.Name)
+		b.Write(name)
+		b.WriteString(": ")
+
+		if err := writeSampleValueJSON(b, s, inner); err != nil {
+			return err
+		}
+	}
+
+	if len(samples) > 0 {
+		b.WriteString("\n" + indent)
+	}
+
+	b.WriteString("}")
+
+	return nil
+}
+
+func writeSampleValueJSON(b *bytes.Buffer, s SampleValue, indent string) error {
+	if !s.IsObject {
+		val, err := json.Marshal(s.Value)
+		b.Write(val)
+
+		return err
+	}
+
+	if !s.Repeated {
+		return writeSampleJSON(b, s.Fields, indent)
+	}
+
+	inner := indent + sampleIndent
+
+	if skelputil.IsBlank(s.Key) {
+		b.WriteString("[\n" + inner)
+		err := writeSampleJSON(b, s.Fields, inner)
+		b.WriteString("\n" + indent + "]")
+
+		return err
+	}
+
+	key, _ := json.Marshal(sampleKey(s))
+	b.WriteString("{\n" + inner)
+	b.Write(key)
+	b.WriteString(": ")
+	err := writeSampleJSON(b, s.Fields, inner)
+	b.WriteString("\n" + indent + "}")
+
+	return err
+}
+
+// SampleYAML writes the samples as a yaml document with the question and rules of each variable
+// as a comment. Values are written in json form, which yaml reads as is.
+func SampleYAML(samples []SampleValue) ([]byte, error) {
+	var b bytes.Buffer
+
+	err := writeSampleYAML(&b, samples, "", "")
+
+	return b.Bytes(), err
+}
+
+// writeSampleYAML writes one line per leaf. first is written in place of the indent on the first
+// line so list items can start with a dash.
+func writeSampleYAML(b *bytes.Buffer, samples []SampleValue, indent, first string) error {
+	for i, s := range samples {
+		lead := indent
+		if i == 0 && first != "" {
+			lead = first
+		}
+
+		name, _ := json.Marshal(s.Name)
+		b.WriteString(lead + string(name) + ":")
+
+		if !s.IsObject {
+			val, err := json.Marshal(s.Value)
+
+			if err != nil {
+				return err
+			}
+
+			b.WriteString(" " + string(val))
+		}
+
+		if !skelputil.IsBlank(s.Comment) {
+			b.WriteString(" # " + s.Comment)
+		}
+
+		b.WriteString("\n")
+
+		if !s.IsObject {
+			continue
+		}
+
+		inner := indent + sampleIndent
+		var err error
+
+		switch {
+		case !s.Repeated:
+			err = writeSampleYAML(b, s.Fields, inner, "")
+		case skelputil.IsBlank(s.Key):
+			err = writeSampleYAML(b, s.Fields, inner+sampleIndent, inner+"- ")
+		default:
+			key, _ := json.Marshal(sampleKey(s))
+			b.WriteString(inner + string(key) + ":\n")
+			err = writeSampleYAML(b, s.Fields, inner+sampleIndent, "")
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sampleKey(s SampleValue) string {
+	for _, f := range s.Fields {
+		if f.Name == s.Key {
+			return stringForValue(f.Value)
+		}
+	}
+
+	return ""
+}
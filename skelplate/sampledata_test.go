@@ -0,0 +1,174 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleDescriptorJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "projectName", "default": "beer", "prompt": "Name your project:", "required": true, "min": 3},
+    {"name": "{{.projectName}}Port", "default": "8080", "type": "int"},
+    {"name": "style", "default": "ipa", "choices": ["ipa", "stout"], "mutlichoice": false},
+    {"name": "tags", "default": ["{{.projectName}}", "hoppy"], "mutlival": true},
+    {"name": "loud", "value": "{{.projectName | upper}}", "computed": true},
+    {"name": "hops", "repeated": true, "key": "hopName", "variables": [{"name": "hopName", "default": "citra"}, {"name": "grams", "default": 20}]},
+    {"name": "owner", "variables": [{"name": "email", "default": "{{.TemplateAuthor}}@example.com"}]}
+  ]
+}`
+
+const sampleJSONWant = `{
+  "projectName": "beer",
+  "beerPort": 8080,
+  "style": "ipa",
+  "tags": ["beer","hoppy"],
+  "hops": {
+    "citra": {
+      "hopName": "citra",
+      "grams": 20
+    }
+  },
+  "owner": {
+    "email": "brainicorn@example.com"
+  }
+}
+`
+
+const sampleYAMLWant = `"projectName": "beer" # Name your project: (string, required, min 3)
+"beerPort": 8080 # Enter a value for beerPort: (int)
+"style": "ipa" # Make a selection for style: (string, one of ipa, stout)
+"tags": ["beer","hoppy"] # Enter a value for tags: (string, list)
+"hops": # Would you like to add another hops:
+  "citra":
+    "hopName": "citra" # Enter a value for hopName: (string)
+    "grams": 20 # Enter a value for grams: (float)
+"owner":
+  "email": "brainicorn@example.com" # Enter a value for email: (string)
+`
+
+func writeSampleSkelplate(t *testing.T) string {
+	dir, _ := ioutil.TempDir("", "skelp-sample-test")
+
+	if err := ioutil.WriteFile(filepath.Join(dir, skelpFilename), []byte(sampleDescriptorJSON), 0644); err != nil {
+		t.Fatalf("error writing descriptor: %s", err)
+	}
+
+	return dir
+}
+
+func TestSampleData(t *testing.T) {
+	dir := writeSampleSkelplate(t)
+	defer os.RemoveAll(dir)
+
+	samples, err := NewDataProvider(nil).SampleData(dir, nil)
+
+	if err != nil {
+		t.Fatalf("error building sample data: %s", err)
+	}
+
+	jsonBytes, err := SampleJSON(samples)
+
+	if err != nil || string(jsonBytes) != sampleJSONWant {
+		t.Errorf("sample json does not match: (%v)\nhave (%s)\nwant (%s)", err, jsonBytes, sampleJSONWant)
+	}
+
+	yamlBytes, err := SampleYAML(samples)
+
+	if err != nil || string(yamlBytes) != sampleYAMLWant {
+		t.Errorf("sample yaml does not match: (%v)\nhave (%s)\nwant (%s)", err, yamlBytes, sampleYAMLWant)
+	}
+}
+
+func TestSampleDataIncludes(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "skelp-sample-include-test")
+	defer os.RemoveAll(dir)
+
+	descriptors := map[string]string{
+		"app": `{"author": "brainicorn", "variables": [{"name": "projectName", "default": "beer"}],
+			"includes": [{"template": "../license", "dir": "license", "data": {"holder": "{{.projectName}} inc"}}]}`,
+		"license": `{"author": "brainicorn", "variables": [{"name": "projectName"}, {"name": "holder"}, {"name": "year", "default": 2017}],
+			"includes": [{"template": "../app"}]}`,
+	}
+
+	for name, descriptor := range descriptors {
+		os.MkdirAll(filepath.Join(dir, name), 0755)
+		ioutil.WriteFile(filepath.Join(dir, name, skelpFilename), []byte(descriptor), 0644)
+	}
+
+	samples, err := NewDataProvider(nil).SampleData(filepath.Join(dir, "app"), nil)
+
+	if err != nil {
+		t.Fatalf("error building sample data: %s", err)
+	}
+
+	want := "{\n  \"projectName\": \"beer\",\n  \"year\": 2017\n}\n"
+	jsonBytes, _ := SampleJSON(samples)
+
+	if string(jsonBytes) != want {
+		t.Errorf("sample json does not match:\nhave (%s)\nwant (%s)", jsonBytes, want)
+	}
+}
+
+func TestSampleDataSchemaViolations(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "skelp-sample-schema-test")
+	defer os.RemoveAll(dir)
+
+	descriptors := map[string]string{
+		"broken":    `{"author": 5, "variables": []}`,
+		"including": `{"author": "brainicorn", "variables": [], "includes": [{"template": "../broken"}]}`,
+	}
+
+	for name, descriptor := range descriptors {
+		os.MkdirAll(filepath.Join(dir, name), 0755)
+		ioutil.WriteFile(filepath.Join(dir, name, skelpFilename), []byte(descriptor), 0644)
+	}
+
+	for name := range descriptors {
+		_, err := NewDataProvider(nil).SampleData(filepath.Join(dir, name), nil)
+
+		if err == nil || !strings.HasPrefix(err.Error(), "Error validating skelp descriptor") {
+			t.Errorf("%s: wrong error: have (%v) want a schema error", name, err)
+		}
+	}
+}
+
+func TestSampleDataFilesApplyWithoutPrompting(t *testing.T) {
+	dir := writeSampleSkelplate(t)
+	defer os.RemoveAll(dir)
+
+	var descriptor SkelplateDescriptor
+	json.Unmarshal([]byte(sampleDescriptorJSON), &descriptor)
+
+	for name, content := range map[string]string{"data.json": sampleJSONWant, "data.yaml": sampleYAMLWant} {
+		t.Run(name, func(t *testing.T) {
+			dataPath := filepath.Join(dir, name)
+			ioutil.WriteFile(dataPath, []byte(content), 0644)
+
+			data, err := ReadDataFile(dataPath)
+
+			if err != nil {
+				t.Fatalf("error reading data file: %s", err)
+			}
+
+			dp := NewDataProvider(data)
+			dp.beforePrompt = func() {
+				t.Fatalf("sample data should not need prompting")
+			}
+
+			filler, err := dp.gatherData(descriptor)
+
+			if err != nil {
+				t.Fatalf("error gathering data: %s", err)
+			}
+
+			if filler["beerPort"] != 8080 || filler["loud"] != "BEER" {
+				t.Errorf("gathered data does not match: have (%v)", filler)
+			}
+		})
+	}
+}
@@ -2,18 +2,33 @@ package skelplate
 
 const (
 	// GithubComBrainicornSkelpSkelplateSkelplateDescriptor is a json-schema accessor
-	GithubComBrainicornSkelpSkelplateSkelplateDescriptor = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","definitions":{"github_com-brainicorn-skelp-skelplate-ComplexVar":{"type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-MultiValue":{"type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default","mutlival"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Selection":{"type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":"array","title":"Choices are the options to display in a select box.","items":{"type":"string"}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-SimpleVar":{"type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."}},"required":["name","default"],"additionalProperties":false}},"properties":{"author":{"type":"string","title":"TemplateAuthor is the author of the template."},"created":{"type":"string","title":"TemplateCreated is the date the template was created.","format":"date-time"},"description":{"type":"string","title":"TemplateDesc is the description of the template."},"modified":{"type":"string","title":"TemplateModified is the date the template was last modified.","format":"date-time"},"repository":{"type":"string","title":"TemplateRepo is the url of the template."},"variables":{"type":"array","title":"TemplateVariables holds the variables and their configuration for processing a template.","items":{"type":"object","title":"TemplateVariable is the base interface for a variable @jsonSchema( anyOf=[\"github.com/brainicorn/skelp/skelplate/SimpleVar\" ,\"github.com/brainicorn/skelp/skelplate/ComplexVar\" ,\"github.com/brainicorn/skelp/skelplate/Selection\" ,\"github.com/brainicorn/skelp/skelplate/MultiValue\"] )","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"}]}}}}`
+	GithubComBrainicornSkelpSkelplateSkelplateDescriptor = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","definitions":{"github_com-brainicorn-skelp-skelplate-Choice":{"type":"object","title":"Choice is a single option in a select box.","properties":{"help":{"type":"string","title":"Help is a short description displayed when the choice has focus."},"label":{"type":"string","title":"Label is the text displayed for the choice (defaults to the value)."},"value":{"type":"string","title":"Value is what templates receive when the choice is selected."}},"required":["value"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ComplexVar":{"type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Computed":{"type":"object","title":"Computed is a variable whose value is derived from the variables before it.","description":"Computed variables are never prompted for.","properties":{"computed":{"type":"boolean","title":"IsComputed designates the variable as computed."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"overridable":{"type":"boolean","title":"Overridable allows the value to be supplied by a data file instead of being computed."},"type":{"type":"string","title":"DataType is the type the computed value is converted to (defaults to string).","enum":["int","float","bool","string","date","path"]},"value":{"type":"string","title":"Value is a golang template that computes the value from previous variables."}},"required":["name","value","computed"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Include":{"type":"object","title":"Include is another template that gets applied after this template.","description":"Answers already gathered for this template are shared with the included template.","properties":{"data":{"type":"object","title":"Data maps variable names of the included template to golang templates that are run","description":"against the data gathered for this template.","additionalProperties":{"type":"string"}},"dir":{"type":"string","title":"Dir is the sub directory of the output directory to apply the included template to.","description":"The dir can be a golang template."},"template":{"type":"string","title":"TemplateID is the path, repository url or alias of the included template.","description":"Relative paths are resolved against the including template."}},"required":["template"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-MultiValue":{"type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlival"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ObjectVar":{"type":"object","title":"ObjectVar gathers a group of nested variables into a single object.","description":"Repeated objects ask whether another should be added, like MultiValue does, and are gathered\ninto a list, or into a map when a key is given.","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another object should be entered."},"key":{"type":"string","title":"Key is the name of a nested variable used to key repeated objects into a map."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"repeated":{"type":"boolean","title":"Repeated allows the user to enter multiple objects."},"variables":{"type":"array","title":"Variables are the nested variables that make up the object.","description":"Nested variables can use the values of the enclosing variables in their templates.","items":{"type":"object","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Computed"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ObjectVar"}]}}},"required":["name","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Section":{"type":"object","title":"Section groups top level variables that are asked for together.","description":"The variables of a section should be next to each other in the variables array.","properties":{"description":{"type":"string","title":"Description is displayed under the title."},"title":{"type":"string","title":"Title is displayed before the prompts of the section's variables."},"variables":{"type":"array","title":"Variables are the names of the variables in the section.","items":{"type":"string"}},"when":{"type":"string","title":"When is a golang template run against the data gathered before the section's first variable.","description":"If it renders false or blank the section's variables are not prompted and take their defaults."}},"required":["title","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Selection":{"type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":["array","string"],"title":"Choices are the options to display in a select box.","description":"Choices can be plain strings, choice objects or a template string that renders a comma\nseparated list of values.","items":{"anyOf":[{"type":"string"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Choice"}]}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"maxPicks":{"type":"integer","title":"MaxPicks is the maximum number of choices that can be picked in a multiple choice selection."},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"minPicks":{"type":"integer","title":"MinPicks is the minimum number of choices that must be picked in a multiple choice selection."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-SimpleVar":{"type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false}},"properties":{"author":{"type":"string","title":"TemplateAuthor is the author of the template."},"created":{"type":"string","title":"TemplateCreated is the date the template was created.","format":"date-time"},"description":{"type":"string","title":"TemplateDesc is the description of the template."},"extends":{"type":"string","title":"Extends is the path, repository url or alias of a template this template builds on.","description":"The parent's variables and templates are used unless this template overrides them."},"includes":{"type":"array","title":"Includes are other templates that are applied along with this template.","items":{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Include"}},"modified":{"type":"string","title":"TemplateModified is the date the template was last modified.","format":"date-time"},"repository":{"type":"string","title":"TemplateRepo is the url of the template."},"sections":{"type":"array","title":"Sections group variables under a title that is displayed before their prompts.","items":{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Section"}},"strict":{"type":"boolean","title":"Strict makes templates fail on keys missing from the data instead of rendering empty values."},"variables":{"type":"array","title":"TemplateVariables holds the variables and their configuration for processing a template.","items":{"type":"object","title":"TemplateVariable is the base interface for a variable @jsonSchema( anyOf=[\"github.com/brainicorn/skelp/skelplate/SimpleVar\" ,\"github.com/brainicorn/skelp/skelplate/ComplexVar\" ,\"github.com/brainicorn/skelp/skelplate/Selection\" ,\"github.com/brainicorn/skelp/skelplate/MultiValue\" ,\"github.com/brainicorn/skelp/skelplate/Computed\" ,\"github.com/brainicorn/skelp/skelplate/ObjectVar\"] )","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Computed"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ObjectVar"}]}}}}`
 
 	// GithubComBrainicornSkelpSkelplateSelection is a json-schema accessor
-	GithubComBrainicornSkelpSkelplateSelection = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":"array","title":"Choices are the options to display in a select box.","items":{"type":"string"}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false}`
+	GithubComBrainicornSkelpSkelplateSelection = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":["array","string"],"title":"Choices are the options to display in a select box.","description":"Choices can be plain strings, choice objects or a template string that renders a comma\nseparated list of values.","items":{"anyOf":[{"type":"string"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Choice"}]}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"maxPicks":{"type":"integer","title":"MaxPicks is the maximum number of choices that can be picked in a multiple choice selection."},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"minPicks":{"type":"integer","title":"MinPicks is the minimum number of choices that must be picked in a multiple choice selection."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false,"definitions":{"github_com-brainicorn-skelp-skelplate-Choice":{"type":"object","title":"Choice is a single option in a select box.","properties":{"help":{"type":"string","title":"Help is a short description displayed when the choice has focus."},"label":{"type":"string","title":"Label is the text displayed for the choice (defaults to the value)."},"value":{"type":"string","title":"Value is what templates receive when the choice is selected."}},"required":["value"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ComplexVar":{"type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Computed":{"type":"object","title":"Computed is a variable whose value is derived from the variables before it.","description":"Computed variables are never prompted for.","properties":{"computed":{"type":"boolean","title":"IsComputed designates the variable as computed."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"overridable":{"type":"boolean","title":"Overridable allows the value to be supplied by a data file instead of being computed."},"type":{"type":"string","title":"DataType is the type the computed value is converted to (defaults to string).","enum":["int","float","bool","string","date","path"]},"value":{"type":"string","title":"Value is a golang template that computes the value from previous variables."}},"required":["name","value","computed"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Include":{"type":"object","title":"Include is another template that gets applied after this template.","description":"Answers already gathered for this template are shared with the included template.","properties":{"data":{"type":"object","title":"Data maps variable names of the included template to golang templates that are run","description":"against the data gathered for this template.","additionalProperties":{"type":"string"}},"dir":{"type":"string","title":"Dir is the sub directory of the output directory to apply the included template to.","description":"The dir can be a golang template."},"template":{"type":"string","title":"TemplateID is the path, repository url or alias of the included template.","description":"Relative paths are resolved against the including template."}},"required":["template"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-MultiValue":{"type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlival"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ObjectVar":{"type":"object","title":"ObjectVar gathers a group of nested variables into a single object.","description":"Repeated objects ask whether another should be added, like MultiValue does, and are gathered\ninto a list, or into a map when a key is given.","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another object should be entered."},"key":{"type":"string","title":"Key is the name of a nested variable used to key repeated objects into a map."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"repeated":{"type":"boolean","title":"Repeated allows the user to enter multiple objects."},"variables":{"type":"array","title":"Variables are the nested variables that make up the object.","description":"Nested variables can use the values of the enclosing variables in their templates.","items":{"type":"object","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Computed"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ObjectVar"}]}}},"required":["name","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Section":{"type":"object","title":"Section groups top level variables that are asked for together.","description":"The variables of a section should be next to each other in the variables array.","properties":{"description":{"type":"string","title":"Description is displayed under the title."},"title":{"type":"string","title":"Title is displayed before the prompts of the section's variables."},"variables":{"type":"array","title":"Variables are the names of the variables in the section.","items":{"type":"string"}},"when":{"type":"string","title":"When is a golang template run against the data gathered before the section's first variable.","description":"If it renders false or blank the section's variables are not prompted and take their defaults."}},"required":["title","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Selection":{"type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":["array","string"],"title":"Choices are the options to display in a select box.","description":"Choices can be plain strings, choice objects or a template string that renders a comma\nseparated list of values.","items":{"anyOf":[{"type":"string"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Choice"}]}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"maxPicks":{"type":"integer","title":"MaxPicks is the maximum number of choices that can be picked in a multiple choice selection."},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"minPicks":{"type":"integer","title":"MinPicks is the minimum number of choices that must be picked in a multiple choice selection."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-SimpleVar":{"type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false}}}`
 
 	// GithubComBrainicornSkelpSkelplateMultiValue is a json-schema accessor
-	GithubComBrainicornSkelpSkelplateMultiValue = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default","mutlival"],"additionalProperties":false}`
+	GithubComBrainicornSkelpSkelplateMultiValue = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlival"],"additionalProperties":false}`
 
 	// GithubComBrainicornSkelpSkelplateSimpleVar is a json-schema accessor
-	GithubComBrainicornSkelpSkelplateSimpleVar = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."}},"required":["name","default"],"additionalProperties":false}`
+	GithubComBrainicornSkelpSkelplateSimpleVar = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false}`
 
 	// GithubComBrainicornSkelpSkelplateComplexVar is a json-schema accessor
-	GithubComBrainicornSkelpSkelplateComplexVar = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."}},"required":["name","default"],"additionalProperties":false}`
+	GithubComBrainicornSkelpSkelplateComplexVar = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false}`
+
+	// GithubComBrainicornSkelpSkelplateChoice is a json-schema accessor
+	GithubComBrainicornSkelpSkelplateChoice = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Choice is a single option in a select box.","properties":{"help":{"type":"string","title":"Help is a short description displayed when the choice has focus."},"label":{"type":"string","title":"Label is the text displayed for the choice (defaults to the value)."},"value":{"type":"string","title":"Value is what templates receive when the choice is selected."}},"required":["value"],"additionalProperties":false}`
+
+	// GithubComBrainicornSkelpSkelplateComputed is a json-schema accessor
+	GithubComBrainicornSkelpSkelplateComputed = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Computed is a variable whose value is derived from the variables before it.","description":"Computed variables are never prompted for.","properties":{"computed":{"type":"boolean","title":"IsComputed designates the variable as computed."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"overridable":{"type":"boolean","title":"Overridable allows the value to be supplied by a data file instead of being computed."},"type":{"type":"string","title":"DataType is the type the computed value is converted to (defaults to string).","enum":["int","float","bool","string","date","path"]},"value":{"type":"string","title":"Value is a golang template that computes the value from previous variables."}},"required":["name","value","computed"],"additionalProperties":false}`
+
+	// GithubComBrainicornSkelpSkelplateInclude is a json-schema accessor
+	GithubComBrainicornSkelpSkelplateInclude = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Include is another template that gets applied after this template.","description":"Answers already gathered for this template are shared with the included template.","properties":{"data":{"type":"object","title":"Data maps variable names of the included template to golang templates that are run","description":"against the data gathered for this template.","additionalProperties":{"type":"string"}},"dir":{"type":"string","title":"Dir is the sub directory of the output directory to apply the included template to.","description":"The dir can be a golang template."},"template":{"type":"string","title":"TemplateID is the path, repository url or alias of the included template.","description":"Relative paths are resolved against the including template."}},"required":["template"],"additionalProperties":false}`
+
+	// GithubComBrainicornSkelpSkelplateObjectVar is a json-schema accessor
+	GithubComBrainicornSkelpSkelplateObjectVar = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"ObjectVar gathers a group of nested variables into a single object.","description":"Repeated objects ask whether another should be added, like MultiValue does, and are gathered\ninto a list, or into a map when a key is given.","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another object should be entered."},"key":{"type":"string","title":"Key is the name of a nested variable used to key repeated objects into a map."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"repeated":{"type":"boolean","title":"Repeated allows the user to enter multiple objects."},"variables":{"type":"array","title":"Variables are the nested variables that make up the object.","description":"Nested variables can use the values of the enclosing variables in their templates.","items":{"type":"object","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Computed"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ObjectVar"}]}}},"required":["name","variables"],"additionalProperties":false,"definitions":{"github_com-brainicorn-skelp-skelplate-Choice":{"type":"object","title":"Choice is a single option in a select box.","properties":{"help":{"type":"string","title":"Help is a short description displayed when the choice has focus."},"label":{"type":"string","title":"Label is the text displayed for the choice (defaults to the value)."},"value":{"type":"string","title":"Value is what templates receive when the choice is selected."}},"required":["value"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ComplexVar":{"type":"object","title":"ComplexVar applies restrictions to input.","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Computed":{"type":"object","title":"Computed is a variable whose value is derived from the variables before it.","description":"Computed variables are never prompted for.","properties":{"computed":{"type":"boolean","title":"IsComputed designates the variable as computed."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"overridable":{"type":"boolean","title":"Overridable allows the value to be supplied by a data file instead of being computed."},"type":{"type":"string","title":"DataType is the type the computed value is converted to (defaults to string).","enum":["int","float","bool","string","date","path"]},"value":{"type":"string","title":"Value is a golang template that computes the value from previous variables."}},"required":["name","value","computed"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Include":{"type":"object","title":"Include is another template that gets applied after this template.","description":"Answers already gathered for this template are shared with the included template.","properties":{"data":{"type":"object","title":"Data maps variable names of the included template to golang templates that are run","description":"against the data gathered for this template.","additionalProperties":{"type":"string"}},"dir":{"type":"string","title":"Dir is the sub directory of the output directory to apply the included template to.","description":"The dir can be a golang template."},"template":{"type":"string","title":"TemplateID is the path, repository url or alias of the included template.","description":"Relative paths are resolved against the including template."}},"required":["template"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-MultiValue":{"type":"object","title":"MultiValue allows the user to enter multiple values.","description":"This is for gathering things like \"tags\"","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another value should be entered."},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlival":{"type":"boolean","title":"IsMultiVal designates the variable as a mutli-value prompt."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlival"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-ObjectVar":{"type":"object","title":"ObjectVar gathers a group of nested variables into a single object.","description":"Repeated objects ask whether another should be added, like MultiValue does, and are gathered\ninto a list, or into a map when a key is given.","properties":{"addPrompt":{"type":"string","title":"AddPrompt is the string to display when asking if another object should be entered."},"key":{"type":"string","title":"Key is the name of a nested variable used to key repeated objects into a map."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"repeated":{"type":"boolean","title":"Repeated allows the user to enter multiple objects."},"variables":{"type":"array","title":"Variables are the nested variables that make up the object.","description":"Nested variables can use the values of the enclosing variables in their templates.","items":{"type":"object","anyOf":[{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-SimpleVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ComplexVar"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Selection"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-MultiValue"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Computed"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-ObjectVar"}]}}},"required":["name","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Section":{"type":"object","title":"Section groups top level variables that are asked for together.","description":"The variables of a section should be next to each other in the variables array.","properties":{"description":{"type":"string","title":"Description is displayed under the title."},"title":{"type":"string","title":"Title is displayed before the prompts of the section's variables."},"variables":{"type":"array","title":"Variables are the names of the variables in the section.","items":{"type":"string"}},"when":{"type":"string","title":"When is a golang template run against the data gathered before the section's first variable.","description":"If it renders false or blank the section's variables are not prompted and take their defaults."}},"required":["title","variables"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-Selection":{"type":"object","title":"Selection represents a configurable \"select box\".","description":"The user can choose multiple values or be restricted to choosing a single value.","properties":{"choices":{"type":["array","string"],"title":"Choices are the options to display in a select box.","description":"Choices can be plain strings, choice objects or a template string that renders a comma\nseparated list of values.","items":{"anyOf":[{"type":"string"},{"$ref":"#/definitions/github_com-brainicorn-skelp-skelplate-Choice"}]}},"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"docsUrl":{"type":"string","title":"DocsURL points at documentation for the variable and is displayed with the help."},"exists":{"type":"boolean","title":"MustExist requires a path variable to point at an existing file or directory."},"help":{"type":"string","title":"Help is displayed when the user asks for help while answering the prompt."},"isDir":{"type":"boolean","title":"IsDir requires a path variable to point at a directory if it exists."},"layout":{"type":"string","title":"Layout is the golang time layout used to parse date variables (defaults to 2006-01-02)."},"max":{"type":"number","title":"Max the maximum value (for numbers) or length (for strings)"},"maxPicks":{"type":"integer","title":"MaxPicks is the maximum number of choices that can be picked in a multiple choice selection."},"min":{"type":"number","title":"Min the minimum value (for numbers) or length (for strings)."},"minPicks":{"type":"integer","title":"MinPicks is the minimum number of choices that must be picked in a multiple choice selection."},"multiline":{"type":"boolean","title":"Multiline asks for a string that spans several lines in $VISUAL or $EDITOR, or inline until a","description":"blank line when neither is set."},"mutlichoice":{"type":"boolean","title":"MultipleChoice designates whether multiple values may be chosen when the choices field is present."},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"password":{"type":"boolean","title":"Password is a flag to turn on input masking for hiding passwords"},"prompt":{"type":"string","title":"Prompt the string to display when asking for a value."},"relativeToOutput":{"type":"boolean","title":"RelativeToOutput resolves relative path variables against the output directory instead of","description":"the working directory when checking them."},"required":{"type":"boolean","title":"Required whether or not a non-empty value is required."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default","mutlichoice","choices"],"additionalProperties":false},"github_com-brainicorn-skelp-skelplate-SimpleVar":{"type":"object","title":"SimpleVar is an object that can express a name value pair @jsonSchema(additionalProperties=false)","description":"\n","properties":{"default":{"type":["string","number","integer","boolean","array"],"title":"Default the default value (can be blank).","description":"\n","additionalProperties":false},"name":{"type":"string","title":"Name is the name of the variable.","description":"The name can be a golang template and can use values gathered from previous\nvariables in the variables array."},"type":{"type":"string","title":"DataType is the type of value the variable holds.","description":"When omitted the type is inferred from the default value.","enum":["int","float","bool","string","date","path"]}},"required":["name","default"],"additionalProperties":false}}}`
+
+	// GithubComBrainicornSkelpSkelplateSection is a json-schema accessor
+	GithubComBrainicornSkelpSkelplateSection = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","title":"Section groups top level variables that are asked for together.","description":"The variables of a section should be next to each other in the variables array.","properties":{"description":{"type":"string","title":"Description is displayed under the title."},"title":{"type":"string","title":"Title is displayed before the prompts of the section's variables."},"variables":{"type":"array","title":"Variables are the names of the variables in the section.","items":{"type":"string"}},"when":{"type":"string","title":"When is a golang template run against the data gathered before the section's first variable.","description":"If it renders false or blank the section's variables are not prompted and take their defaults."}},"required":["title","variables"],"additionalProperties":false}`
 
 )
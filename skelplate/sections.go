@@ -0,0 +1,127 @@
+package skelplate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+// sectionTracker follows the sections of a descriptor while its variables are gathered.
+type sectionTracker struct {
+	byVar   map[string]*Section
+	skipped map[*Section]bool
+	started map[*Section]bool
+	current *Section
+}
+
+func newSectionTracker(sections []Section) *sectionTracker {
+	st := &sectionTracker{
+		byVar:   make(map[string]*Section),
+		skipped: make(map[*Section]bool),
+		started: make(map[*Section]bool),
+	}
+
+	for i := range sections {
+		for _, name := range sections[i].Variables {
+			if _, exists := st.byVar[name]; !exists {
+				st.byVar[name] = &sections[i]
+			}
+		}
+	}
+
+	return st
+}
+
+// progress counts the questions asked while gathering data.
+// The total grows when another item of a repeated object is added and shrinks when a section is skipped.
+type progress struct {
+	asked int
+	total int
+}
+
+// enterSection makes the section of the top level variable the current section and reports whether
+// the section is skipped. The when template of a section is run the first time one of its variables
+// is reached.
+func (sdp *SkelplateDataProvider) enterSection(v TemplateVariable, scope map[string]interface{}) (bool, error) {
+	st := sdp.sections
+	section := st.byVar[v.Name()]
+	st.current = section
+
+	if section == nil {
+		return false, nil
+	}
+
+	if _, evaluated := st.skipped[section]; !evaluated {
+		show, err := sdp.runStringTemplate(section.When, scope)
+
+		if err != nil {
+			return false, fmt.Errorf("unable to parse section when template: %s - %s", section.Title, err)
+		}
+
+		st.skipped[section] = !skelputil.IsBlank(section.When) && !isTruthy(show)
+	}
+
+	return st.skipped[section], nil
+}
+
+// isTruthy reports whether a rendered when template means the section should be asked.
+func isTruthy(rendered string) bool {
+	rendered = strings.TrimSpace(rendered)
+
+	if b, err := strconv.ParseBool(rendered); err == nil {
+		return b
+	}
+
+	return rendered != "" && rendered != "0" && rendered != "<no value>"
+}
+
+// describePosition adds the current section and the progress to a prompt.
+// The first prompt of a section is marked so the section header can be displayed before it.
+func (sdp *SkelplateDataProvider) describePosition(vp *VariablePrompt, counted bool) {
+	st := sdp.sections
+
+	if counted {
+		sdp.progress.asked++
+		vp.Number = sdp.progress.asked
+		vp.Total = sdp.progress.total
+	}
+
+	if st == nil || st.current == nil {
+		return
+	}
+
+	vp.Section = st.current
+	vp.SectionStart = !st.started[st.current]
+	st.started[st.current] = true
+}
+
+// countQuestions returns the number of prompts needed to gather the variables, counting a single item
+// for repeated objects.
+func countQuestions(vars []TemplateVariable, provided, shared map[string]interface{}) int {
+	count := 0
+
+	for _, v := range vars {
+		if _, isComputed := v.(*Computed); isComputed {
+			continue
+		}
+
+		if _, isProvided := provided[v.Name()]; isProvided {
+			continue
+		}
+
+		if _, isShared := shared[v.Name()]; isShared {
+			continue
+		}
+
+		if ov, isObject := v.(*ObjectVar); isObject {
+			count += countQuestions(ov.Variables, nil, nil)
+			continue
+		}
+
+		count++
+	}
+
+	return count
+}
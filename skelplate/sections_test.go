@@ -0,0 +1,122 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/core"
+	"github.com/brainicorn/skelp/prompter"
+)
+
+const sectionsDescriptorJSON = `{
+  "author": "brainicorn",
+  "variables": [
+    {"name": "projectName", "default": "beer"},
+    {"name": "useDb", "default": false},
+    {"name": "dbHost", "default": "localhost"},
+    {"name": "dbPort", "type": "int", "default": 5432},
+    {"name": "style", "default": "ipa"},
+    {"name": "taps", "repeated": true, "variables": [{"name": "abv", "default": 5}]}
+  ],
+  "sections": [
+    {"title": "Project", "description": "about the project", "variables": ["projectName", "useDb"]},
+    {"title": "Database", "when": "{{.useDb}}", "variables": ["dbHost", "dbPort"]},
+    {"title": "Beer", "variables": ["style", "taps"]}
+  ]
+}`
+
+func TestSections(t *testing.T) {
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(sectionsDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	var tests = []struct {
+		answers  []interface{}
+		expected map[string]interface{}
+		prompts  []string
+	}{
+		{
+			[]interface{}{"ale", false, "stout", float64(6), true, float64(4), false},
+			map[string]interface{}{"projectName": "ale", "useDb": false, "dbHost": "localhost", "dbPort": 5432, "style": "stout",
+				"taps": []interface{}{map[string]interface{}{"abv": float64(6)}, map[string]interface{}{"abv": float64(4)}}},
+			[]string{"Project* 1/6 projectName", "Project 2/6 useDb", "Beer* 3/4 style", "Beer 4/4 taps[0].abv",
+				"Beer 0/0 taps", "Beer 5/5 taps[1].abv", "Beer 0/0 taps"},
+		},
+		{
+			[]interface{}{"ale", true, "db.local", 3306, "stout", float64(6), false},
+			map[string]interface{}{"projectName": "ale", "useDb": true, "dbHost": "db.local", "dbPort": 3306, "style": "stout",
+				"taps": []interface{}{map[string]interface{}{"abv": float64(6)}}},
+			[]string{"Project* 1/6 projectName", "Project 2/6 useDb", "Database* 3/6 dbHost", "Database 4/6 dbPort",
+				"Beer* 5/6 style", "Beer 6/6 taps[0].abv", "Beer 0/0 taps"},
+		},
+	}
+
+	for i, tt := range tests {
+		fpp := &fakePromptProvider{answers: tt.answers}
+
+		dp := NewDataProvider(nil)
+		dp.SetPromptProvider(fpp)
+
+		data, err := dp.gatherData(descriptor)
+
+		if err != nil {
+			t.Fatalf("%d: error gathering data: %s", i, err)
+		}
+
+		for k := range builtinData(descriptor) {
+			delete(data, k)
+		}
+
+		if !reflect.DeepEqual(data, tt.expected) {
+			t.Errorf("%d: wrong data:\nhave (%v)\nwant (%v)", i, data, tt.expected)
+		}
+
+		prompts := []string{}
+		for _, vp := range fpp.asked {
+			title := vp.Section.Title
+			if vp.SectionStart {
+				title += "*"
+			}
+
+			prompts = append(prompts, fmt.Sprintf("%s %d/%d %s", title, vp.Number, vp.Total, vp.Name))
+		}
+
+		if !reflect.DeepEqual(prompts, tt.prompts) {
+			t.Errorf("%d: wrong prompts:\nhave (%v)\nwant (%v)", i, prompts, tt.prompts)
+		}
+	}
+}
+
+func TestSectionHeaders(t *testing.T) {
+	core.DisableColor = true
+
+	var descriptor SkelplateDescriptor
+	if err := json.Unmarshal([]byte(sectionsDescriptorJSON), &descriptor); err != nil {
+		t.Fatalf("error reading descriptor: %s", err)
+	}
+
+	term := prompter.NewScriptedTerminal("", "", "", "", "n")
+
+	dp := NewDataProvider(nil)
+	dp.SetTerminal(term)
+
+	if _, err := dp.gatherData(descriptor); err != nil {
+		t.Fatalf("error gathering data: %s", err)
+	}
+
+	out := term.Output()
+
+	for _, want := range []string{"\nProject\nabout the project\n", "(1/6) Enter a value for projectName:", "\nBeer\n", "(4/4) Enter a value for taps[0].abv:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing (%q):\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "Database") {
+		t.Errorf("skipped section should not be displayed:\n%s", out)
+	}
+}
@@ -0,0 +1,64 @@
+package skelplate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const (
+	errMissingKey   = "variable %q: missing key %q"
+	errMissingKeyAt = "%s:%d: %s"
+)
+
+// missingKeyError is returned when a template of a variable uses a key missing from the data in strict mode.
+type missingKeyError struct {
+	varname string
+	key     string
+}
+
+func (mke *missingKeyError) Error() string {
+	return fmt.Sprintf(errMissingKey, mke.varname, mke.key)
+}
+
+// missingKey turns the error of a strict template of v into a missingKeyError when it's about a missing key.
+func missingKey(v TemplateVariable, err error) error {
+	if _, _, key, ok := skelputil.ParseMissingKey(err); ok {
+		return &missingKeyError{varname: v.Name(), key: key}
+	}
+
+	return err
+}
+
+// StrictProviderFunc reports whether the template files of the template at templateRoot should fail
+// on missing keys, either because UseStrictMode was called or because its descriptor sets strict.
+func (sdp *SkelplateDataProvider) StrictProviderFunc(templateRoot string) (bool, error) {
+	var err error
+	var descriptorBytes []byte
+	var descriptor struct {
+		Strict bool `json:"strict"`
+	}
+
+	if sdp.strict {
+		return true, nil
+	}
+
+	descriptorBytes, err = sdp.descriptorBytes(templateRoot)
+
+	if err == nil {
+		err = json.Unmarshal(descriptorBytes, &descriptor)
+	}
+
+	return descriptor.Strict, err
+}
+
+// templateOptions returns the options for descriptor templates. They fail on missing keys when strict
+// mode is used or the descriptor being gathered sets strict.
+func (sdp *SkelplateDataProvider) templateOptions() []string {
+	if sdp.strict || sdp.strictDesc {
+		return skelputil.StrictTemplateOptions()
+	}
+
+	return sdp.tOptions
+}
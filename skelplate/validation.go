@@ -0,0 +1,238 @@
+package skelplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brainicorn/skelp/prompter"
+)
+
+const (
+	ErrInvalidProvidedData = "invalid values in provided data:\n"
+	errNotAChoice          = "%q is not one of the available choices (%s)"
+	errRequiredValues      = "at least one value is required"
+	errRequiredPick        = "a choice is required"
+	errTooFewPicks         = "at least %d choices must be picked"
+	errTooManyPicks        = "at most %d choices can be picked"
+	errComputedOverride    = "computed value can not be overridden"
+)
+
+// validateProvidedValue runs the same rules the prompts enforce against a value that came from
+// the data map and returns a message for every rule that fails.
+func validateProvidedValue(tvar TemplateVariable, varname, vtype string, val, defval interface{}, outputDir string) []string {
+	violations := []string{}
+	cv := complexVarFor(tvar)
+
+	if sel, ok := tvar.(*Selection); ok {
+		violations = pickViolations(providedChoices(val), sel.Choices.Values(), sel.MultipleChoice, cv.Required, sel.MinPicks, sel.MaxPicks)
+
+		return prefixViolations(varname, violations)
+	}
+
+	if vals, ok := val.([]interface{}); ok {
+		if cv.Required && len(vals) < 1 {
+			violations = append(violations, errRequiredValues)
+		}
+
+		violations = prefixViolations(varname, violations)
+
+		// each value gets the rules of a single value, without the default of the list
+		for i, elem := range vals {
+			violations = append(violations, validateScalarValue(cv, fmt.Sprintf("%s[%d]", varname, i), vtype, elem, nil, outputDir)...)
+		}
+
+		return violations
+	}
+
+	return validateScalarValue(cv, varname, vtype, val, defval, outputDir)
+}
+
+// pickViolations checks that every pick is one of the choice values and that the number of picks
+// is within the limits of the selection.
+func pickViolations(picks, values []string, multiple, required bool, minPicks, maxPicks int) []string {
+	violations := []string{}
+
+	if required && len(picks) < 1 {
+		if multiple {
+			violations = append(violations, errRequiredValues)
+		} else {
+			violations = append(violations, errRequiredPick)
+		}
+	}
+
+	for _, choice := range picks {
+		if !containsString(values, choice) {
+			violations = append(violations, fmt.Sprintf(errNotAChoice, choice, strings.Join(values, ",")))
+		}
+	}
+
+	if multiple && minPicks > 0 && len(picks) < minPicks {
+		violations = append(violations, fmt.Sprintf(errTooFewPicks, minPicks))
+	}
+
+	if multiple && maxPicks > 0 && len(picks) > maxPicks {
+		violations = append(violations, fmt.Sprintf(errTooManyPicks, maxPicks))
+	}
+
+	return violations
+}
+
+func validateScalarValue(cv ComplexVar, varname, vtype string, val, defval interface{}, outputDir string) []string {
+	violations := []string{}
+	prompt := prompter.Prompt{Validators: []prompter.Validator{}}
+	configureDefaultAndValidators(&prompt, cv, vtype, defval, outputDir)
+
+	strval := stringForValue(val)
+	for _, validator := range prompt.Validators {
+		if verr := validator(strval); verr != nil {
+			violations = append(violations, strings.TrimSuffix(verr.Error(), ", please try again."))
+		}
+	}
+
+	return prefixViolations(varname, violations)
+}
+
+// providedTypeMatches checks that a provided value has the JSON type expected for the variable.
+func providedTypeMatches(val interface{}, vtype string, defval interface{}) bool {
+	if _, isSlice := defval.([]interface{}); isSlice {
+		vals, ok := val.([]interface{})
+		if !ok {
+			return false
+		}
+
+		for _, elem := range vals {
+			if !scalarTypeMatches(elem, vtype) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return scalarTypeMatches(val, vtype)
+}
+
+func scalarTypeMatches(val interface{}, vtype string) bool {
+	var ok bool
+
+	switch vtype {
+	case VarTypeInt, VarTypeFloat:
+		_, ok = val.(float64)
+	case VarTypeBool:
+		_, ok = val.(bool)
+	default:
+		_, ok = val.(string)
+	}
+
+	return ok
+}
+
+// convertProvidedValue turns a provided value that has passed validation into its typed form.
+func convertProvidedValue(val interface{}, vtype, layout string, defval interface{}) (interface{}, error) {
+	if vals, ok := val.([]interface{}); ok {
+		typedSlice := []interface{}{}
+
+		for _, elem := range vals {
+			tv, err := convertValue(stringForValue(elem), vtype, layout)
+
+			if err != nil {
+				return nil, err
+			}
+
+			typedSlice = append(typedSlice, tv)
+		}
+
+		return typedSlice, nil
+	}
+
+	return convertValue(stringForValue(val), vtype, layout)
+}
+
+func newProvidedDataError(violations []string) error {
+	var errBuf bytes.Buffer
+
+	errBuf.WriteString(ErrInvalidProvidedData)
+	for _, v := range violations {
+		errBuf.WriteString(fmt.Sprintf("  - %s\n", v))
+	}
+
+	return errors.New(errBuf.String())
+}
+
+func complexVarFor(tvar TemplateVariable) ComplexVar {
+	var cv ComplexVar
+
+	switch ttv := tvar.(type) {
+	case *SimpleVar:
+		cv = ComplexVar{SimpleVar: *ttv}
+	case *ComplexVar:
+		cv = *ttv
+	case *MultiValue:
+		cv = ttv.ComplexVar
+	case *Selection:
+		cv = ttv.ComplexVar
+	}
+
+	return cv
+}
+
+// providedChoices returns the individual selections in a provided value.
+// Selections can be provided as a comma separated string or as an array.
+func providedChoices(val interface{}) []string {
+	choices := []string{}
+
+	switch tval := val.(type) {
+	case string:
+		if len(tval) > 0 {
+			choices = strings.Split(tval, ",")
+		}
+	case []interface{}:
+		for _, elem := range tval {
+			choices = append(choices, stringForValue(elem))
+		}
+	default:
+		choices = append(choices, stringForValue(val))
+	}
+
+	return choices
+}
+
+func stringForValue(val interface{}) string {
+	var s string
+
+	switch tval := val.(type) {
+	case string:
+		s = tval
+	case float64:
+		s = strconv.FormatFloat(tval, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(tval)
+	case nil:
+		s = ""
+	default:
+		s = fmt.Sprintf("%v", val)
+	}
+
+	return s
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
+func prefixViolations(varname string, violations []string) []string {
+	for i, v := range violations {
+		violations[i] = fmt.Sprintf("%s: %s", varname, v)
+	}
+
+	return violations
+}
@@ -0,0 +1,382 @@
+// Package skelptest renders the test cases of a skelplate and compares them to their expected output.
+//
+// A skelplate keeps its cases in a tests folder next to skelp.json. Each case is a folder holding
+// an optional data.json (or data.yaml) file and an expected folder with the files the template
+// should generate for that data:
+//
+//	tests/
+//	  basic/
+//	    data.json
+//	    expected/
+//	      README.md
+//
+// Cases are rendered without prompting. Variables missing from the data file take their defaults.
+// Keys missing from the data fail the case when the template is strict.
+//
+// Template repositories can run their cases from go test:
+//
+//	func TestSkelplate(t *testing.T) {
+//		skelptest.Test(t, ".")
+//	}
+package skelptest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/brainicorn/skelp/generator"
+	"github.com/brainicorn/skelp/provider"
+	"github.com/brainicorn/skelp/skelplate"
+	"github.com/brainicorn/skelp/skelputil"
+)
+
+const (
+	TestsDirname    = "tests"
+	ExpectedDirname = "expected"
+
+	ErrNoTestCases = "no test cases found in %s"
+
+	problemRender     = "unable to render case: %s"
+	problemMissing    = "%s: expected file was not generated"
+	problemUnexpected = "%s: generated file is not expected"
+	problemDiffers    = "%s:%d: want %q have %q"
+	problemTypeDiffer = "%s: expected a %s but a %s was generated"
+)
+
+var dataFilenames = []string{"data.json", "data.yaml", "data.yml"}
+
+// Options control how test cases are run.
+type Options struct {
+	// Update replaces the expected folder of every case with the rendered output.
+	Update bool
+
+	// SkelpOptions are used to find and render the template. OutputDir and the providers are
+	// set for each case.
+	SkelpOptions generator.SkelpOptions
+}
+
+// DefaultOptions checks the cases of local templates without downloading anything.
+func DefaultOptions() Options {
+	opts := generator.DefaultOptions()
+	opts.Download = false
+	opts.CheckForUpdates = false
+
+	return Options{SkelpOptions: opts}
+}
+
+// CaseResult is the outcome of a single test case.
+type CaseResult struct {
+	Name     string   `json:"name"`
+	Problems []string `json:"problems,omitempty"`
+	Updated  bool     `json:"updated,omitempty"`
+}
+
+// Passed reports whether the case rendered exactly its expected output.
+func (cr CaseResult) Passed() bool {
+	return len(cr.Problems) < 1
+}
+
+// Test runs every case of the template as a subtest of t.
+func Test(t *testing.T, templateID string) {
+	results, err := RunCases(templateID, DefaultOptions())
+
+	if err != nil {
+		t.Fatalf("unable to run skelplate tests: %s", err)
+	}
+
+	for _, result := range results {
+		problems := result.Problems
+		t.Run(result.Name, func(t *testing.T) {
+			for _, p := range problems {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+// RunCases renders every case of the template in a temp directory and compares the output to the
+// case's expected folder, or replaces the expected folder when opts.Update is set.
+func RunCases(templateID string, opts Options) ([]CaseResult, error) {
+	var err error
+	var templateRoot string
+	var caseNames []string
+
+	gen := generator.New(opts.SkelpOptions)
+
+	templateRoot, err = gen.LocalTemplatePath(templateID)
+
+	if err == nil {
+		templateRoot, err = filepath.Abs(templateRoot)
+	}
+
+	if err == nil {
+		caseNames, err = testCases(filepath.Join(templateRoot, TestsDirname))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := []CaseResult{}
+
+	for _, name := range caseNames {
+		var result CaseResult
+		result, err = runCase(templateRoot, name, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func testCases(testsDir string) ([]string, error) {
+	names := []string{}
+
+	if !skelputil.PathExists(testsDir) {
+		return nil, fmt.Errorf(ErrNoTestCases, testsDir)
+	}
+
+	infos, err := ioutil.ReadDir(testsDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fi := range infos {
+		if fi.IsDir() {
+			names = append(names, fi.Name())
+		}
+	}
+
+	if len(names) < 1 {
+		return nil, fmt.Errorf(ErrNoTestCases, testsDir)
+	}
+
+	return names, nil
+}
+
+func runCase(templateRoot, name string, opts Options) (CaseResult, error) {
+	var err error
+	var outDir string
+
+	result := CaseResult{Name: name}
+	caseDir := filepath.Join(templateRoot, TestsDirname, name)
+	expectedDir := filepath.Join(caseDir, ExpectedDirname)
+
+	outDir, err = ioutil.TempDir("", "skelp-test-case")
+
+	if err != nil {
+		return result, err
+	}
+
+	defer os.RemoveAll(outDir)
+
+	if rerr := renderCase(templateRoot, caseDir, outDir, opts.SkelpOptions); rerr != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf(problemRender, rerr))
+		return result, nil
+	}
+
+	if opts.Update {
+		err = os.RemoveAll(expectedDir)
+
+		if err == nil {
+			err = copyTree(outDir, expectedDir)
+		}
+
+		result.Updated = err == nil
+		return result, err
+	}
+
+	result.Problems, err = compareTrees(expectedDir, outDir)
+
+	return result, err
+}
+
+func renderCase(templateRoot, caseDir, outDir string, skelpOpts generator.SkelpOptions) error {
+	var err error
+	var data map[string]interface{}
+
+	for _, fname := range dataFilenames {
+		dataPath := filepath.Join(caseDir, fname)
+
+		if skelputil.PathExists(dataPath) {
+			data, err = skelplate.ReadDataFile(dataPath)
+			break
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	dp := skelplate.NewDataProvider(data)
+	dp.UseDefaults()
+	dp.SetOutputDir(outDir)
+
+	if skelpOpts.Strict {
+		dp.UseStrictMode()
+	}
+
+	skelpOpts.OutputDir = outDir
+	skelpOpts.OverwriteProvider = provider.AlwaysOverwriteProvider
+	skelpOpts.IncludesProvider = dp.IncludesProviderFunc
+	skelpOpts.ParentProvider = dp.ParentProviderFunc
+	skelpOpts.StrictProvider = dp.StrictProviderFunc
+	skelpOpts.FuncsReceiver = dp.SetFuncs
+
+	return generator.New(skelpOpts).Generate(templateRoot, dp.DataProviderFunc)
+}
+
+// compareTrees returns a problem for every file that differs between the expected and actual trees.
+func compareTrees(expectedDir, actualDir string) ([]string, error) {
+	var err error
+	var expected, actual map[string]os.FileInfo
+	var problems []string
+
+	expected, err = listTree(expectedDir)
+
+	if err == nil {
+		actual, err = listTree(actualDir)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for rel := range expected {
+		paths = append(paths, rel)
+	}
+
+	for rel := range actual {
+		if _, ok := expected[rel]; !ok {
+			paths = append(paths, rel)
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, rel := range paths {
+		efi, inExpected := expected[rel]
+		afi, inActual := actual[rel]
+
+		switch {
+		case !inActual:
+			problems = append(problems, fmt.Sprintf(problemMissing, rel))
+		case !inExpected:
+			problems = append(problems, fmt.Sprintf(problemUnexpected, rel))
+		case efi.IsDir() != afi.IsDir():
+			problems = append(problems, fmt.Sprintf(problemTypeDiffer, rel, kindOf(efi), kindOf(afi)))
+		case !efi.IsDir():
+			var problem string
+			problem, err = compareFiles(rel, filepath.Join(expectedDir, rel), filepath.Join(actualDir, rel))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func listTree(root string) (map[string]os.FileInfo, error) {
+	tree := make(map[string]os.FileInfo)
+
+	if !skelputil.PathExists(root) {
+		return tree, nil
+	}
+
+	err := filepath.Walk(root, func(curPath string, fi os.FileInfo, werr error) error {
+		if werr != nil || curPath == root {
+			return werr
+		}
+
+		rel, _ := filepath.Rel(root, curPath)
+		tree[filepath.ToSlash(rel)] = fi
+
+		return nil
+	})
+
+	return tree, err
+}
+
+// compareFiles describes the first line that differs between the files, or returns "" if they match.
+func compareFiles(rel, expectedPath, actualPath string) (string, error) {
+	var err error
+	var want, have []byte
+
+	want, err = ioutil.ReadFile(expectedPath)
+
+	if err == nil {
+		have, err = ioutil.ReadFile(actualPath)
+	}
+
+	if err != nil || bytes.Equal(want, have) {
+		return "", err
+	}
+
+	wantLines := strings.Split(string(want), "\n")
+	haveLines := strings.Split(string(have), "\n")
+
+	for i := 0; ; i++ {
+		var wantLine, haveLine string
+
+		if i < len(wantLines) {
+			wantLine = wantLines[i]
+		}
+
+		if i < len(haveLines) {
+			haveLine = haveLines[i]
+		}
+
+		if wantLine != haveLine || i >= len(wantLines) || i >= len(haveLines) {
+			return fmt.Sprintf(problemDiffers, rel, i+1, wantLine, haveLine), nil
+		}
+	}
+}
+
+func kindOf(fi os.FileInfo) string {
+	if fi.IsDir() {
+		return "directory"
+	}
+
+	return "file"
+}
+
+func copyTree(src, dest string) error {
+	return filepath.Walk(src, func(curPath string, fi os.FileInfo, werr error) error {
+		if werr != nil {
+			return werr
+		}
+
+		rel, _ := filepath.Rel(src, curPath)
+		target := filepath.Join(dest, rel)
+
+		if fi.IsDir() {
+			return os.MkdirAll(target, os.ModePerm)
+		}
+
+		content, err := ioutil.ReadFile(curPath)
+
+		if err == nil {
+			err = ioutil.WriteFile(target, content, fi.Mode())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,96 @@
+package skelptest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSkelplateCases(t *testing.T) {
+	Test(t, "../testdata/skelptest/beer")
+}
+
+func TestRunCasesPass(t *testing.T) {
+	results, err := RunCases("../testdata/skelptest/beer", DefaultOptions())
+
+	if err != nil {
+		t.Fatalf("error running cases: %s", err)
+	}
+
+	want := []CaseResult{{Name: "defaults"}, {Name: "ipa"}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results do not match: have (%+v) want (%+v)", results, want)
+	}
+}
+
+func TestRunCasesProblems(t *testing.T) {
+	results, err := RunCases("../testdata/skelptest/broken", DefaultOptions())
+
+	if err != nil {
+		t.Fatalf("error running cases: %s", err)
+	}
+
+	want := []string{
+		`README.md:3: want "brewed by someone else" have "brewed by brainicorn"`,
+		"old.txt: expected file was not generated",
+		"style.txt: generated file is not expected",
+	}
+
+	if len(results) != 1 || results[0].Passed() || !reflect.DeepEqual(results[0].Problems, want) {
+		t.Errorf("results do not match: have (%+v) want (%v)", results, want)
+	}
+}
+
+func TestRunCasesStrict(t *testing.T) {
+	results, err := RunCases("../testdata/skelptest/strict", DefaultOptions())
+
+	if err != nil {
+		t.Fatalf("error running cases: %s", err)
+	}
+
+	want := []string{"unable to render case: missing keys in strict mode:\n  README.md:3: missing key \"brewr\""}
+
+	if len(results) != 1 || results[0].Passed() || !reflect.DeepEqual(results[0].Problems, want) {
+		t.Errorf("results do not match: have (%+v) want (%q)", results, want)
+	}
+}
+
+func TestRunCasesUpdate(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "skelp-test-update")
+	defer os.RemoveAll(tmpDir)
+
+	if err := copyTree("../testdata/skelptest/broken", tmpDir); err != nil {
+		t.Fatalf("error copying template: %s", err)
+	}
+
+	opts := DefaultOptions()
+	opts.Update = true
+
+	results, err := RunCases(tmpDir, opts)
+
+	if err != nil || len(results) != 1 || !results[0].Updated {
+		t.Fatalf("cases were not updated: (%v) %+v", err, results)
+	}
+
+	results, err = RunCases(tmpDir, DefaultOptions())
+
+	if err != nil || len(results) != 1 || !results[0].Passed() {
+		t.Errorf("updated cases should pass: (%v) %+v", err, results)
+	}
+
+	style, _ := ioutil.ReadFile(filepath.Join(tmpDir, TestsDirname, "stale", ExpectedDirname, "style.txt"))
+	if string(style) != "stout\n" {
+		t.Errorf("expected output was not updated: have (%s)", style)
+	}
+}
+
+func TestRunCasesNoTests(t *testing.T) {
+	_, err := RunCases("../testdata/generator/simple", DefaultOptions())
+
+	if err == nil || !strings.HasPrefix(err.Error(), "no test cases found in") {
+		t.Errorf("error does not match: have (%v)", err)
+	}
+}
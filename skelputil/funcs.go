@@ -0,0 +1,325 @@
+package skelputil
+
+import (
+	"crypto/rand"
+	"fmt"
+	"go/token"
+	"math/big"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Masterminds/sprig"
+)
+
+const (
+	ErrUnknownLicense = "unknown license %q, available licenses are: %s"
+
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLength      = 26
+)
+
+var (
+	licenseYears   = []string{"[year]", "[yyyy]"}
+	licenseHolders = []string{"[fullname]", "[name of copyright owner]"}
+
+	uncountables = map[string]bool{
+		"data": true, "deer": true, "equipment": true, "feedback": true, "fish": true, "information": true,
+		"metadata": true, "news": true, "series": true, "sheep": true, "software": true, "species": true,
+	}
+
+	irregulars = map[string]string{
+		"child": "children", "foot": "feet", "goose": "geese", "knife": "knives", "life": "lives",
+		"man": "men", "mouse": "mice", "person": "people", "tooth": "teeth", "wife": "wives", "woman": "women",
+	}
+)
+
+// SkelpFuncs returns the scaffolding functions skelp adds to the sprig functions.
+func SkelpFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"camelCase":          CamelCase,
+		"pascalCase":         PascalCase,
+		"kebabCase":          KebabCase,
+		"snakeCase":          SnakeCase,
+		"screamingSnakeCase": ScreamingSnakeCase,
+		"goIdentifier":       GoIdentifier,
+		"goPackage":          GoPackage,
+		"pluralize":          Pluralize,
+		"license":            License,
+		"uuid":               sprig.FuncMap()["uuidv4"],
+		"ulid":               ULID,
+		"gitUserName":        func() string { return gitConfig("user.name") },
+		"gitUserEmail":       func() string { return gitConfig("user.email") },
+		"nowIn":              NowIn,
+	}
+}
+
+// Words splits a string into words on spaces, punctuation and changes of case.
+// An acronym stays a single word, e.g. "HTTPServer" is "HTTP" and "Server".
+func Words(s string) []string {
+	words := []string{}
+	word := []rune{}
+	runes := []rune(s)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = []rune{}
+			}
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if !unicode.IsUpper(prev) || nextIsLower {
+				words = append(words, string(word))
+				word = []rune{}
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// CamelCase converts "my project" to "myProject".
+func CamelCase(s string) string {
+	words := Words(s)
+
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = capitalize(w)
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// PascalCase converts "my project" to "MyProject".
+func PascalCase(s string) string {
+	words := Words(s)
+
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+
+	return strings.Join(words, "")
+}
+
+// KebabCase converts "my project" to "my-project".
+func KebabCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "-"))
+}
+
+// SnakeCase converts "my project" to "my_project".
+func SnakeCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "_"))
+}
+
+// ScreamingSnakeCase converts "my project" to "MY_PROJECT".
+func ScreamingSnakeCase(s string) string {
+	return strings.ToUpper(strings.Join(Words(s), "_"))
+}
+
+// GoIdentifier converts a string to a camel case go identifier. Identifiers that would start with a
+// digit are prefixed with an underscore and keywords get an underscore suffix.
+func GoIdentifier(s string) string {
+	ident := CamelCase(s)
+
+	switch {
+	case ident == "":
+		return "_"
+	case unicode.IsDigit([]rune(ident)[0]):
+		return "_" + ident
+	case token.Lookup(ident).IsKeyword():
+		return ident + "_"
+	}
+
+	return ident
+}
+
+// GoPackage converts a string to a go package name made of lower case letters and digits.
+// Names that would be empty, start with a digit or be a keyword get a "pkg" prefix.
+func GoPackage(s string) string {
+	pkg := []rune{}
+
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			pkg = append(pkg, r)
+		}
+	}
+
+	name := string(pkg)
+
+	if name == "" || unicode.IsDigit(pkg[0]) || token.Lookup(name).IsKeyword() {
+		return "pkg" + name
+	}
+
+	return name
+}
+
+// Pluralize returns the english plural of a noun, keeping its case. Only the last word of an
+// identifier is pluralized, e.g. "userAccount" is "userAccounts" and "HTTPServer" is "HTTPServers".
+func Pluralize(word string) string {
+	words := Words(word)
+
+	if len(words) < 1 {
+		return word
+	}
+
+	last := words[len(words)-1]
+	start := strings.LastIndex(word, last)
+
+	return word[:start] + pluralizeWord(last) + word[start+len(last):]
+}
+
+func pluralizeWord(word string) string {
+	lower := strings.ToLower(word)
+	plural := lower
+
+	switch {
+	case uncountables[lower]:
+		return word
+	case irregulars[lower] != "":
+		plural = irregulars[lower]
+	case strings.HasSuffix(lower, "sis"):
+		plural = strings.TrimSuffix(lower, "is") + "es"
+	case hasAnySuffix(lower, "s", "x", "z", "ch", "sh"):
+		plural = lower + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		plural = strings.TrimSuffix(lower, "y") + "ies"
+	default:
+		plural = lower + "s"
+	}
+
+	switch {
+	case len(word) > 1 && word == strings.ToUpper(word):
+		return strings.ToUpper(plural)
+	case unicode.IsUpper([]rune(word)[0]):
+		return capitalize(plural)
+	}
+
+	return plural
+}
+
+// License returns the text of a license by SPDX identifier with the copyright year set to the current
+// year. The copyright holder is filled in when one is given.
+func License(id string, holder ...string) (string, error) {
+	var text string
+
+	for spdx, t := range licenses {
+		if strings.EqualFold(spdx, id) {
+			text = t
+		}
+	}
+
+	if text == "" {
+		return "", fmt.Errorf(ErrUnknownLicense, id, strings.Join(LicenseIDs(), ", "))
+	}
+
+	for _, year := range licenseYears {
+		text = strings.Replace(text, year, strconv.Itoa(time.Now().Year()), -1)
+	}
+
+	if len(holder) > 0 && !IsBlank(holder[0]) {
+		for _, h := range licenseHolders {
+			text = strings.Replace(text, h, holder[0], -1)
+		}
+	}
+
+	return text, nil
+}
+
+// LicenseIDs returns the SPDX identifiers of the licenses known to License.
+func LicenseIDs() []string {
+	ids := []string{}
+	for id := range licenses {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// ULID returns a new lexicographically sortable identifier made of the current time in milliseconds
+// and 80 random bits, encoded in crockford base32.
+func ULID() (string, error) {
+	var id [16]byte
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> uint(40-8*i))
+	}
+
+	if _, err := rand.Read(id[6:]); err != nil {
+		return "", err
+	}
+
+	n := new(big.Int).SetBytes(id[:])
+	base := big.NewInt(32)
+	digit := new(big.Int)
+	encoded := make([]byte, ulidLength)
+
+	for i := ulidLength - 1; i >= 0; i-- {
+		n.DivMod(n, base, digit)
+		encoded[i] = crockfordBase32[digit.Int64()]
+	}
+
+	return string(encoded), nil
+}
+
+// NowIn formats the current time in a timezone like "America/New_York", "UTC" or "Local".
+func NowIn(timezone, layout string) (string, error) {
+	loc, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		return "", err
+	}
+
+	return time.Now().In(loc).Format(layout), nil
+}
+
+// gitConfig returns the git config value of key, or an empty string when it's not set or git is missing.
+func gitConfig(key string) string {
+	out, err := exec.Command("git", "config", "--get", key).Output()
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes)
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
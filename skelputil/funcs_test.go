@@ -0,0 +1,146 @@
+package skelputil
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+var caseTests = []struct {
+	fn       func(string) string
+	input    string
+	expected string
+}{
+	{CamelCase, "my project", "myProject"},
+	{CamelCase, "HTTPServer", "httpServer"},
+	{CamelCase, "my-project_name", "myProjectName"},
+	{PascalCase, "my project", "MyProject"},
+	{PascalCase, "parseJSONBody", "ParseJsonBody"},
+	{KebabCase, "MyProject v2", "my-project-v2"},
+	{KebabCase, "  spaced   out  ", "spaced-out"},
+	{SnakeCase, "myProject", "my_project"},
+	{ScreamingSnakeCase, "my-project", "MY_PROJECT"},
+	{GoIdentifier, "my-project", "myProject"},
+	{GoIdentifier, "2 fast", "_2Fast"},
+	{GoIdentifier, "type", "type_"},
+	{GoIdentifier, "---", "_"},
+	{GoPackage, "My-Project 2", "myproject2"},
+	{GoPackage, "99 beers", "pkg99beers"},
+	{GoPackage, "func", "pkgfunc"},
+	{Pluralize, "beer", "beers"},
+	{Pluralize, "Brewery", "Breweries"},
+	{Pluralize, "day", "days"},
+	{Pluralize, "box", "boxes"},
+	{Pluralize, "match", "matches"},
+	{Pluralize, "knife", "knives"},
+	{Pluralize, "analysis", "analyses"},
+	{Pluralize, "person", "people"},
+	{Pluralize, "Sheep", "Sheep"},
+	{Pluralize, "API", "APIS"},
+	{Pluralize, "UserAccount", "UserAccounts"},
+	{Pluralize, "userAccount", "userAccounts"},
+	{Pluralize, "HTTPServer", "HTTPServers"},
+	{Pluralize, "user_category", "user_categories"},
+	{Pluralize, "Wife", "Wives"},
+	{Pluralize, "safe", "safes"},
+	{Pluralize, "cafe", "cafes"},
+	{Pluralize, "giraffe", "giraffes"},
+}
+
+func TestCaseFuncs(t *testing.T) {
+	for i, tt := range caseTests {
+		if have := tt.fn(tt.input); have != tt.expected {
+			t.Errorf("%d: wrong conversion of (%s), have (%s) want (%s)", i, tt.input, have, tt.expected)
+		}
+	}
+}
+
+func TestLicense(t *testing.T) {
+	text, err := License("mit", "Brainicorn")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "Copyright (c) " + strconv.Itoa(time.Now().Year()) + " Brainicorn"
+	if !strings.HasPrefix(text, "MIT License") || !strings.Contains(text, want) {
+		t.Errorf("wrong license text, want (%s) in:\n%s", want, text)
+	}
+
+	text, _ = License("Apache-2.0")
+	if !strings.Contains(text, "[name of copyright owner]") {
+		t.Errorf("holder placeholder should be kept without a holder:\n%s", text)
+	}
+
+	if _, err = License("beerware"); err == nil || !strings.Contains(err.Error(), "MIT") {
+		t.Errorf("wrong error for unknown license: %v", err)
+	}
+}
+
+func TestULID(t *testing.T) {
+	first, err := ULID()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	second, _ := ULID()
+
+	if !regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`).MatchString(first) {
+		t.Errorf("invalid ulid (%s)", first)
+	}
+
+	if first[:10] >= second[:10] {
+		t.Errorf("ulids should sort by time, have (%s) then (%s)", first, second)
+	}
+}
+
+func TestNowIn(t *testing.T) {
+	have, err := NowIn("UTC", "2006")
+
+	if err != nil || have != strconv.Itoa(time.Now().UTC().Year()) {
+		t.Errorf("wrong time, have (%s, %v)", have, err)
+	}
+
+	if _, err = NowIn("Middle/Earth", "2006"); err == nil {
+		t.Error("unknown timezone should be an error")
+	}
+}
+
+func TestFunctionMapHasSkelpFuncs(t *testing.T) {
+	tmpl, err := template.New("funcs").Funcs(FunctionMap()).Parse(`{{"hoppy brew" | pascalCase}} {{"ipa" | upper}} {{uuid | len}} {{gitUserName | printf "%T"}}`)
+
+	if err != nil {
+		t.Fatalf("unable to parse template: %s", err)
+	}
+
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, nil); err != nil {
+		t.Fatalf("unable to execute template: %s", err)
+	}
+
+	if out.String() != "HoppyBrew IPA 36 string" {
+		t.Errorf("wrong output, have (%s) want (%s)", out.String(), "HoppyBrew IPA 36 string")
+	}
+}
+
+func TestFunctionMapWithPrecedence(t *testing.T) {
+	fmap := FunctionMapWith(map[string]interface{}{"kebabCase": strings.ToUpper})
+
+	if fn, ok := fmap["kebabCase"].(func(string) string); !ok || fn("my project") != "MY PROJECT" {
+		t.Error("caller funcs should replace skelp funcs")
+	}
+
+	if _, ok := fmap["upper"]; !ok {
+		t.Error("sprig funcs should be kept")
+	}
+
+	if err := CheckFuncs(map[string]interface{}{"not-valid": strings.ToUpper}); err == nil {
+		t.Error("invalid func names should be rejected")
+	}
+}
@@ -1,27 +1,99 @@
 package skelputil
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/Masterminds/sprig"
 )
 
+var missingKeyPattern = regexp.MustCompile(`:(\d+):\d+: executing "[^"]*" at <([^>]*)>: map has no entry for key "([^"]*)"`)
+
 const (
-	missingKeyOption = "missingkey=zero"
+	ErrInvalidFuncs = "invalid template functions: %v"
+
+	missingKeyOption       = "missingkey=zero"
+	strictMissingKeyOption = "missingkey=error"
 )
 
+// FunctionMap returns the functions available to templates: the sprig functions and SkelpFuncs.
 func FunctionMap() map[string]interface{} {
+	return FunctionMapWith(nil)
+}
+
+// FunctionMapWith returns FunctionMap with funcs added. A function in funcs replaces a skelp or sprig
+// function with the same name, and a skelp function replaces a sprig function.
+func FunctionMapWith(funcs map[string]interface{}) map[string]interface{} {
 	fmap := sprig.FuncMap()
 
+	for name, fn := range SkelpFuncs() {
+		fmap[name] = fn
+	}
+
+	for name, fn := range funcs {
+		fmap[name] = fn
+	}
+
 	return fmap
 }
 
+// CheckFuncs returns an error when funcs can't be used by a template, e.g. when a name isn't a valid
+// identifier or a value isn't a function returning one value or a value and an error.
+func CheckFuncs(funcs map[string]interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf(ErrInvalidFuncs, r)
+		}
+	}()
+
+	template.New("funcs").Funcs(funcs)
+
+	return nil
+}
+
 func TemplateOptions() []string {
 	return []string{missingKeyOption}
 }
 
+// StrictTemplateOptions makes templates fail on missing keys instead of rendering zero values.
+func StrictTemplateOptions() []string {
+	return []string{strictMissingKeyOption}
+}
+
+// ParseMissingKey returns the line, the field chain (e.g. .owner.email) and the key of the error a
+// strict template returns for a missing key. ok is false for other errors.
+func ParseMissingKey(err error) (line int, chain, key string, ok bool) {
+	if err == nil {
+		return 0, "", "", false
+	}
+
+	match := missingKeyPattern.FindStringSubmatch(err.Error())
+
+	if match == nil {
+		return 0, "", "", false
+	}
+
+	line, _ = strconv.Atoi(match[1])
+
+	return line, match[2], match[3], true
+}
+
+// IsStrict reports whether template options fail on missing keys.
+func IsStrict(options []string) bool {
+	for _, opt := range options {
+		if opt == strictMissingKeyOption {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Check if a file or directory exists.
 func PathExists(path string) bool {
 	// note: the err is either IsNotExist or something else